@@ -0,0 +1,11 @@
+module day1
+
+go 1.21
+
+require (
+    sciencerocketry.com/window v0.0.0
+)
+
+replace (
+    sciencerocketry.com/window => ./window
+)
@@ -0,0 +1,3 @@
+module sciencerocketry.com/window
+
+go 1.21
@@ -0,0 +1,110 @@
+package window
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseFunc converts a line of input into a number.
+type ParseFunc[T Number] func(s string) (T, error)
+
+// ParseInt() parses a line holding a decimal integer.
+func ParseInt(s string) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(s))
+}
+
+// ParseFloat() parses a line holding a floating point number.
+func ParseFloat(s string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(s), 64)
+}
+
+// Scanner slides a window over the numbers read from an io.Reader, one per line, so
+// the input never has to be held in memory. Blank lines are skipped. Like a
+// bufio.Scanner, Scan() is called until it returns false and then Err() reports any
+// error that stopped it.
+type Scanner[T Number] struct {
+	lines  *bufio.Scanner
+	parse  ParseFunc[T]
+	window *Window[T]
+	line   int
+	err    error
+}
+
+// NewScanner() creates a Scanner with a window of 'size' values that reads from 'r'.
+func NewScanner[T Number](r io.Reader, size int, parse ParseFunc[T]) (*Scanner[T], error) {
+	w, err := New[T](size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Scanner[T]{lines: bufio.NewScanner(r), parse: parse, window: w}, nil
+}
+
+// Scan() reads values until the window is full and has moved along by one value. It
+// returns false at the end of the input or on an error.
+func (s *Scanner[T]) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	for s.lines.Scan() {
+		s.line++
+
+		text := s.lines.Text()
+		if len(strings.TrimSpace(text)) == 0 {
+			continue
+		}
+
+		v, err := s.parse(text)
+		if err != nil {
+			s.err = fmt.Errorf("line %d: %w", s.line, err)
+			return false
+		}
+
+		s.window.Push(v)
+		if s.window.Full() {
+			return true
+		}
+	}
+
+	s.err = s.lines.Err()
+
+	return false
+}
+
+// Window() returns the window after the latest call to Scan().
+func (s *Scanner[T]) Window() *Window[T] {
+	return s.window
+}
+
+// Err() returns the error that stopped the Scanner, if any.
+func (s *Scanner[T]) Err() error {
+	return s.err
+}
+
+// StreamIncreases() returns the number of times the sum of a window of 'size' values
+// read from 'r' is larger than the sum of the window before it.
+func StreamIncreases[T Number](r io.Reader, size int, parse ParseFunc[T]) (int, error) {
+	s, err := NewScanner(r, size, parse)
+	if err != nil {
+		return 0, err
+	}
+
+	increases := 0
+	first := true
+
+	var previous T
+	for s.Scan() {
+		sum := s.Window().Sum()
+		if !first && sum > previous {
+			increases++
+		}
+
+		previous, first = sum, false
+	}
+
+	return increases, s.Err()
+}
@@ -0,0 +1,201 @@
+// Package window computes statistics over a sliding window of numbers. The window
+// keeps running totals and monotonic queues, so each new value updates the sum,
+// minimum, maximum and mean in amortised O(1) time however large the window is.
+package window
+
+import (
+	"fmt"
+)
+
+// Number is any integer or floating point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Aggregate is a statistic of the values in a window.
+type Aggregate int
+
+const (
+	Sum Aggregate = iota
+	Min
+	Max
+	Mean
+)
+
+// String() returns the name of the aggregate.
+func (a Aggregate) String() string {
+	switch a {
+	case Sum:
+		return "sum"
+	case Min:
+		return "min"
+	case Max:
+		return "max"
+	case Mean:
+		return "mean"
+	}
+
+	return fmt.Sprintf("Aggregate(%d)", int(a))
+}
+
+// entry is a value in a monotonic queue along with its position in the input.
+type entry[T Number] struct {
+	index int
+	value T
+}
+
+// Window holds the most recent values pushed to it, up to its size.
+type Window[T Number] struct {
+	size   int
+	values []T
+	pushed int
+	sum    T
+
+	// the candidates for the minimum (increasing) and maximum (decreasing) - each queue
+	// starts with the current minimum or maximum
+	minimums, maximums []entry[T]
+}
+
+// New() creates an empty Window that holds 'size' values.
+func New[T Number](size int) (*Window[T], error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("the window size must be at least 1 (%d)", size)
+	}
+
+	return &Window[T]{size: size, values: make([]T, size)}, nil
+}
+
+// Size() returns the number of values the window holds when it is full.
+func (w *Window[T]) Size() int {
+	return w.size
+}
+
+// Len() returns the number of values in the window.
+func (w *Window[T]) Len() int {
+	return min(w.pushed, w.size)
+}
+
+// Full() reports whether the window holds 'size' values.
+func (w *Window[T]) Full() bool {
+	return w.pushed >= w.size
+}
+
+// Push() adds a value to the window, dropping the oldest value if it is full.
+func (w *Window[T]) Push(v T) {
+	slot := w.pushed % w.size
+	if w.Full() {
+		w.sum -= w.values[slot]
+	}
+
+	w.values[slot] = v
+	w.sum += v
+
+	// values that can never be the minimum or maximum again are dropped from the back
+	// of the queues, and values that have left the window from the front
+	oldest := w.pushed - w.size + 1
+
+	for len(w.minimums) > 0 && w.minimums[len(w.minimums)-1].value >= v {
+		w.minimums = w.minimums[:len(w.minimums)-1]
+	}
+
+	w.minimums = append(w.minimums, entry[T]{index: w.pushed, value: v})
+	for w.minimums[0].index < oldest {
+		w.minimums = w.minimums[1:]
+	}
+
+	for len(w.maximums) > 0 && w.maximums[len(w.maximums)-1].value <= v {
+		w.maximums = w.maximums[:len(w.maximums)-1]
+	}
+
+	w.maximums = append(w.maximums, entry[T]{index: w.pushed, value: v})
+	for w.maximums[0].index < oldest {
+		w.maximums = w.maximums[1:]
+	}
+
+	w.pushed++
+}
+
+// Sum() returns the sum of the values in the window.
+func (w *Window[T]) Sum() T {
+	return w.sum
+}
+
+// Min() returns the smallest value in the window, or 0 if it is empty.
+func (w *Window[T]) Min() T {
+	if len(w.minimums) == 0 {
+		return 0
+	}
+
+	return w.minimums[0].value
+}
+
+// Max() returns the largest value in the window, or 0 if it is empty.
+func (w *Window[T]) Max() T {
+	if len(w.maximums) == 0 {
+		return 0
+	}
+
+	return w.maximums[0].value
+}
+
+// Mean() returns the mean of the values in the window, or 0 if it is empty.
+func (w *Window[T]) Mean() float64 {
+	if w.Len() == 0 {
+		return 0
+	}
+
+	return float64(w.sum) / float64(w.Len())
+}
+
+// Value() returns the specified aggregate of the values in the window.
+func (w *Window[T]) Value(a Aggregate) float64 {
+	switch a {
+	case Min:
+		return float64(w.Min())
+	case Max:
+		return float64(w.Max())
+	case Mean:
+		return w.Mean()
+	}
+
+	return float64(w.Sum())
+}
+
+// Slide() moves a window of 'size' values along 'values' and returns the aggregate at
+// each position where the window is full - there are len(values)-size+1 of them.
+func Slide[T Number](values []T, size int, a Aggregate) ([]float64, error) {
+	w, err := New[T](size)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []float64
+	for _, v := range values {
+		w.Push(v)
+		if w.Full() {
+			results = append(results, w.Value(a))
+		}
+	}
+
+	return results, nil
+}
+
+// CountIncreases() returns the number of times the sum of a window of 'size' values is
+// larger than the sum of the window before it.
+func CountIncreases[T Number](values []T, size int) (int, error) {
+	sums, err := Slide(values, size, Sum)
+	if err != nil {
+		return 0, err
+	}
+
+	increases := 0
+	for i := 1; i < len(sums); i++ {
+		if sums[i] > sums[i-1] {
+			increases++
+		}
+	}
+
+	return increases, nil
+}
@@ -0,0 +1,73 @@
+package window
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var sample = []int{199, 200, 208, 210, 200, 207, 240, 269, 260, 263}
+
+// TestCountIncreases() checks both parts of the puzzle for the example.
+func TestCountIncreases(t *testing.T) {
+	for _, test := range []struct{ size, want int }{{1, 7}, {3, 5}} {
+		got, err := CountIncreases(sample, test.size)
+		if err != nil || got != test.want {
+			t.Errorf("CountIncreases(%d):\nwant %d\ngot  %d (%v)\n", test.size, test.want, got, err)
+		}
+	}
+}
+
+var slideTests = []struct {
+	aggregate Aggregate
+	want      []float64
+}{
+	{Sum, []float64{607, 618, 618, 617, 647, 716, 769, 792}},
+	{Min, []float64{199, 200, 200, 200, 200, 207, 240, 260}},
+	{Max, []float64{208, 210, 210, 210, 240, 269, 269, 269}},
+	{Mean, []float64{607.0 / 3, 206, 206, 617.0 / 3, 647.0 / 3, 716.0 / 3, 769.0 / 3, 264}},
+}
+
+// TestSlide() checks each aggregate over a window of three values.
+func TestSlide(t *testing.T) {
+	for _, test := range slideTests {
+		got, err := Slide(sample, 3, test.aggregate)
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Slide(%s):\nwant %v\ngot  %v (%v)\n", test.aggregate, test.want, got, err)
+		}
+	}
+
+	if _, err := Slide(sample, 0, Sum); err == nil {
+		t.Errorf("Slide(): expected an error for a window of size 0\n")
+	}
+}
+
+// TestScanner() reads the example from a stream, including floating point values and
+// a value that can't be parsed.
+func TestScanner(t *testing.T) {
+	input := "199\n200\n208\n210\n\n200\n207\n240\n269\n260\n263\n"
+
+	got, err := StreamIncreases(strings.NewReader(input), 3, ParseInt)
+	if err != nil || got != 5 {
+		t.Errorf("StreamIncreases():\nwant %d\ngot  %d (%v)\n", 5, got, err)
+	}
+
+	s, err := NewScanner(strings.NewReader("1.5\n2.5\n-1\n"), 2, ParseFloat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var means []float64
+	for s.Scan() {
+		means = append(means, s.Window().Mean())
+	}
+
+	if s.Err() != nil || !reflect.DeepEqual(means, []float64{2, 0.75}) {
+		t.Errorf("Scanner.Mean():\nwant %v\ngot  %v (%v)\n", []float64{2, 0.75}, means, s.Err())
+	}
+
+	_, err = StreamIncreases(strings.NewReader("1\n2\nthree\n"), 1, ParseInt)
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3:") {
+		t.Errorf("StreamIncreases():\nwant an error on line 3\ngot  %v\n", err)
+	}
+}
@@ -1,88 +1,78 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"strconv"
-	"strings"
 )
 
+// main() prints where the submarine ends up under each set of rules. It receives the
+// name of the data file containing the commands:
+//
+//	day2 [-strict] [-trajectory course.csv] day2.txt
+//
+// -strict rejects unknown commands and -trajectory writes the course taken under the
+// aim rules for plotting.
 func main() {
-	var inputFile string
-	inputFile = os.Args[1]
+	strict := flag.Bool("strict", false, "reject unknown commands instead of ignoring them")
+	trajectoryFile := flag.String("trajectory", "", "write the part two trajectory to this CSV file")
+	flag.Parse()
+
+	inputFile := flag.Arg(0)
 
 	fileContents, err := ReadFile(inputFile)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	position, depth, navigateError := navigate(fileContents)
-	if navigateError != nil {
-		log.Fatal(navigateError)
+	program, err := ParseInstructions(fileContents)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	fmt.Printf("Part One - horizontal position: %d, depth: %d, multiplied %d\n", position, depth, position*depth)
-
-	position, depth, navigateError = navigateWithAim(fileContents)
-	if navigateError != nil {
-		log.Fatal(navigateError)
+	trajectory, err := navigate(program, PlainRules, *strict)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	fmt.Printf("Part Two - horizontal position: %d, depth: %d, multiplied %d\n", position, depth, position*depth)
-}
+	final := trajectory.Final()
+	fmt.Printf("Part One - horizontal position: %d, depth: %d, multiplied %d\n", final.Position, final.Depth, final.Position*final.Depth)
 
-func navigate(fileContents []string) (position int, depth int, err error) {
-	position, depth = 0, 0
-	for i, line := range fileContents[0:] {
-		instruction := strings.Split(line, " ")
-		if len(instruction) <= 1 {
-			return -1, -1, fmt.Errorf("navigate - could not parse the specified instruction at line %d", i)
-		}
+	trajectory, err = navigate(program, AimRules, *strict)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-		magnitude, err := strconv.Atoi(instruction[1])
-		if err != nil {
-			return -1, -1, err
-		}
+	final = trajectory.Final()
+	fmt.Printf("Part Two - horizontal position: %d, depth: %d, multiplied %d\n", final.Position, final.Depth, final.Position*final.Depth)
 
-		switch instruction[0] {
-		case "forward":
-			position += magnitude
-		case "up":
-			depth -= magnitude
-		case "down":
-			depth += magnitude
+	if len(*trajectoryFile) > 0 {
+		if err := writeTrajectory(*trajectoryFile, trajectory); err != nil {
+			log.Fatal(err)
 		}
 	}
-
-	return
 }
 
-func navigateWithAim(fileContents []string) (position int, depth int, err error) {
-	position, depth = 0, 0
-	aim := 0
+// navigate() runs the program with the specified rules.
+func navigate(program []Instruction, rules RuleSet, strict bool) (Trajectory, error) {
+	in := NewInterpreter(rules)
+	in.Strict = strict
 
-	for i, line := range fileContents[0:] {
-		instruction := strings.Split(line, " ")
-		if len(instruction) <= 1 {
-			return -1, -1, fmt.Errorf("navigateWithAim - could not parse the specified instruction at line %d", i)
-		}
+	return in.Run(program)
+}
 
-		magnitude, err := strconv.Atoi(instruction[1])
-		if err != nil {
-			return -1, -1, err
-		}
+// writeTrajectory() writes the trajectory to the named CSV file.
+func writeTrajectory(filename string, trajectory Trajectory) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
 
-		switch instruction[0] {
-		case "forward":
-			position += magnitude
-			depth += aim * magnitude
-		case "up":
-			aim -= magnitude
-		case "down":
-			aim += magnitude
-		}
+	if err := trajectory.WriteCSV(file); err != nil {
+		file.Close()
+		return err
 	}
 
-	return
+	return file.Close()
 }
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+var sample = []string{
+	"forward 5",
+	"down 5",
+	"forward 8",
+	"up 3",
+	"down 8",
+	"forward 2",
+}
+
+var ruleTests = []struct {
+	name  string
+	rules RuleSet
+	want  State
+}{
+	{"PlainRules", PlainRules, State{Position: 15, Depth: 10}},
+	{"AimRules", AimRules, State{Position: 15, Depth: 60, Aim: 10}},
+	{"reversing", RuleSet{
+		"forward": PlainRules["forward"],
+		"back":    func(s *State, x int) { s.Position -= x },
+		"down":    PlainRules["down"],
+	}, State{Position: 15, Depth: 13}},
+}
+
+// TestRun() runs the puzzle's example with each set of rules.
+func TestRun(t *testing.T) {
+	program, err := ParseInstructions(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range ruleTests {
+		trajectory, err := NewInterpreter(test.rules).Run(program)
+		if err != nil {
+			t.Errorf("Run(%s): %v\n", test.name, err)
+			continue
+		}
+
+		if got := trajectory.Final(); got != test.want {
+			t.Errorf("Run(%s):\nwant %+v\ngot  %+v\n", test.name, test.want, got)
+		}
+	}
+}
+
+// TestStrict() checks that strict mode rejects unknown commands.
+func TestStrict(t *testing.T) {
+	program, err := ParseInstructions([]string{"forward 1", "sideways 2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := NewInterpreter(PlainRules)
+	if _, err := in.Run(program); err != nil {
+		t.Errorf("Run(): unexpected error %v\n", err)
+	}
+
+	in.Strict = true
+	if _, err := in.Run(program); err == nil {
+		t.Errorf("Run(): expected an error for 'sideways' in strict mode\n")
+	}
+
+	if _, err := ParseInstructions([]string{"forward"}); err == nil {
+		t.Errorf("ParseInstructions(): expected an error for a missing argument\n")
+	}
+}
+
+// TestTrajectory() checks the recorded course.
+func TestTrajectory(t *testing.T) {
+	program, _ := ParseInstructions(sample[:3])
+	trajectory, err := NewInterpreter(AimRules).Run(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := trajectory.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "step,position,depth,aim\n0,0,0,0\n1,5,0,0\n2,5,0,5\n3,13,40,5\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV():\nwant %s\ngot  %s\n", want, buf.String())
+	}
+}
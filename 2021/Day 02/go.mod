@@ -0,0 +1,3 @@
+module day2
+
+go 1.21
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Instruction is a single command for the submarine, such as 'forward 5'.
+type Instruction struct {
+	Opcode   string
+	Argument int
+	// Line is the line of the input the instruction came from.
+	Line int
+}
+
+// ParseInstructions() parses each line of the input into an Instruction, ignoring
+// blank lines.
+func ParseInstructions(input []string) ([]Instruction, error) {
+	var program []Instruction
+
+	for i, line := range input {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: could not parse the instruction '%s'", i+1, line)
+		}
+
+		argument, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: the argument of '%s' isn't a number", i+1, line)
+		}
+
+		program = append(program, Instruction{Opcode: fields[0], Argument: argument, Line: i + 1})
+	}
+
+	return program, nil
+}
+
+// State is the position of the submarine.
+type State struct {
+	Position int
+	Depth    int
+	Aim      int
+}
+
+// Rule applies an instruction's argument to the state of the submarine.
+type Rule func(s *State, argument int)
+
+// RuleSet maps each opcode to the Rule that carries it out.
+type RuleSet map[string]Rule
+
+// PlainRules are the commands as first understood - 'up' and 'down' change the depth.
+var PlainRules = RuleSet{
+	"forward": func(s *State, x int) { s.Position += x },
+	"up":      func(s *State, x int) { s.Depth -= x },
+	"down":    func(s *State, x int) { s.Depth += x },
+}
+
+// AimRules are the commands as described in the manual - 'up' and 'down' change the
+// aim, and moving forward changes the depth by the aim.
+var AimRules = RuleSet{
+	"forward": func(s *State, x int) { s.Position += x; s.Depth += s.Aim * x },
+	"up":      func(s *State, x int) { s.Aim -= x },
+	"down":    func(s *State, x int) { s.Aim += x },
+}
+
+// Interpreter runs a program of instructions with a set of rules.
+type Interpreter struct {
+	Rules RuleSet
+	// Strict rejects instructions with an opcode that isn't in the rules, rather than
+	// ignoring them.
+	Strict bool
+}
+
+// NewInterpreter() creates an Interpreter that ignores unknown opcodes.
+func NewInterpreter(rules RuleSet) *Interpreter {
+	return &Interpreter{Rules: rules}
+}
+
+// Trajectory is the state of the submarine at the start and after each instruction.
+type Trajectory []State
+
+// Run() carries out each instruction in the program starting from the surface and
+// returns the trajectory of the submarine.
+func (in *Interpreter) Run(program []Instruction) (Trajectory, error) {
+	trajectory := make(Trajectory, 1, len(program)+1)
+
+	s := State{}
+	for _, instruction := range program {
+		rule, ok := in.Rules[instruction.Opcode]
+		if !ok {
+			if in.Strict {
+				return nil, fmt.Errorf("line %d: unknown command '%s'", instruction.Line, instruction.Opcode)
+			}
+
+			continue
+		}
+
+		rule(&s, instruction.Argument)
+		trajectory = append(trajectory, s)
+	}
+
+	return trajectory, nil
+}
+
+// Final() returns the state of the submarine at the end of the trajectory.
+func (t Trajectory) Final() State {
+	return t[len(t)-1]
+}
+
+// WriteCSV() writes the trajectory as comma-separated values for plotting.
+func (t Trajectory) WriteCSV(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "step,position,depth,aim\n"); err != nil {
+		return err
+	}
+
+	for step, s := range t {
+		if _, err := fmt.Fprintf(w, "%d,%d,%d,%d\n", step, s.Position, s.Depth, s.Aim); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
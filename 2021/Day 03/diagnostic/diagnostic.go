@@ -0,0 +1,159 @@
+// Package diagnostic decodes the submarine's binary diagnostic report. Each line of the
+// report is stored as a uint64 bitset, so a report can have any width up to 64 bits.
+package diagnostic
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// MaxWidth is the widest report value that fits in a bitset.
+const MaxWidth = 64
+
+// Report is the list of values in a diagnostic report, all of the same width.
+type Report struct {
+	Values []uint64
+	Width  int
+}
+
+// Parse() converts lines of '0's and '1's into a Report, ignoring blank lines. The
+// width comes from the first value, and every other value must have the same width.
+func Parse(lines []string) (*Report, error) {
+	r := &Report{}
+
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		if r.Width == 0 {
+			if len(line) > MaxWidth {
+				return nil, fmt.Errorf("line %d: the value is %d bits wide, but the maximum is %d", i+1, len(line), MaxWidth)
+			}
+
+			r.Width = len(line)
+		}
+
+		if len(line) != r.Width {
+			return nil, fmt.Errorf("line %d: the value is %d bits wide instead of %d", i+1, len(line), r.Width)
+		}
+
+		var value uint64
+		for _, c := range line {
+			switch c {
+			case '0':
+				value <<= 1
+			case '1':
+				value = value<<1 | 1
+			default:
+				return nil, fmt.Errorf("line %d: '%s' isn't a binary value", i+1, line)
+			}
+		}
+
+		r.Values = append(r.Values, value)
+	}
+
+	if len(r.Values) == 0 {
+		return nil, fmt.Errorf("the report has no values")
+	}
+
+	return r, nil
+}
+
+// mask() returns a bitset with the bits of the report's width set.
+func (r *Report) mask() uint64 {
+	if r.Width == MaxWidth {
+		return ^uint64(0)
+	}
+
+	return 1<<r.Width - 1
+}
+
+// Format() returns a value as a string of '0's and '1's the width of the report.
+func (r *Report) Format(value uint64) string {
+	return fmt.Sprintf("%0*b", r.Width, value)
+}
+
+// OneCounts() returns the number of values with a 1 in each bit, where index 0 is the
+// least significant bit. Only the set bits of each value are visited.
+func (r *Report) OneCounts() []int {
+	counts := make([]int, r.Width)
+	for _, value := range r.Values {
+		for v := value; v != 0; v &= v - 1 {
+			counts[bits.TrailingZeros64(v)]++
+		}
+	}
+
+	return counts
+}
+
+// Gamma() returns the gamma rate - the most common bit in each position. It's an error
+// for a position to have as many 0s as 1s.
+func (r *Report) Gamma() (uint64, error) {
+	var gamma uint64
+	for bit, ones := range r.OneCounts() {
+		zeroes := len(r.Values) - ones
+		switch {
+		case ones > zeroes:
+			gamma |= 1 << bit
+		case ones == zeroes:
+			return 0, fmt.Errorf("bit %d has an equivalent number of 0s and 1s", r.Width-1-bit)
+		}
+	}
+
+	return gamma, nil
+}
+
+// Epsilon() returns the epsilon rate - the least common bit in each position.
+func (r *Report) Epsilon() (uint64, error) {
+	gamma, err := r.Gamma()
+	if err != nil {
+		return 0, err
+	}
+
+	return ^gamma & r.mask(), nil
+}
+
+// OxygenRating() returns the oxygen generator rating - the value left after keeping the
+// values with the most common bit (1 on a tie) in each position, from the left.
+func (r *Report) OxygenRating() uint64 {
+	return r.filter(true)
+}
+
+// CO2Rating() returns the CO2 scrubber rating - the value left after keeping the values
+// with the least common bit (0 on a tie) in each position, from the left.
+func (r *Report) CO2Rating() uint64 {
+	return r.filter(false)
+}
+
+// filter() narrows the values down to one by their bits from the left. The values are
+// sorted, so the ones sharing the bits kept so far are always a contiguous range, and
+// within that range the values with a 0 in the next position come before those with a
+// 1. Each step is a binary search for that split rather than a copy of the values.
+func (r *Report) filter(mostCommon bool) uint64 {
+	values := slices.Clone(r.Values)
+	slices.Sort(values)
+
+	lo, hi := 0, len(values)
+	for bit := r.Width - 1; bit >= 0 && hi-lo > 1; bit-- {
+		split := lo + sort.Search(hi-lo, func(i int) bool { return values[lo+i]&(1<<bit) != 0 })
+		zeroes, ones := split-lo, hi-split
+
+		if zeroes == 0 || ones == 0 {
+			// every value left has the same bit, so none are removed
+			continue
+		}
+
+		if keepOnes := ones >= zeroes; keepOnes == mostCommon {
+			lo = split
+		} else {
+			hi = split
+		}
+	}
+
+	return values[lo]
+}
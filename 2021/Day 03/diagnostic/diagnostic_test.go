@@ -0,0 +1,84 @@
+package diagnostic
+
+import (
+	"strings"
+	"testing"
+)
+
+var sample = []string{
+	"00100", "11110", "10110", "10111", "10101", "01111",
+	"00111", "11100", "10000", "11001", "00010", "01010",
+}
+
+// TestSample() checks the puzzle's answers for the example report.
+func TestSample(t *testing.T) {
+	r, err := Parse(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gamma, err := r.Gamma()
+	if err != nil || gamma != 22 {
+		t.Errorf("Gamma():\nwant %d\ngot  %d (%v)\n", 22, gamma, err)
+	}
+
+	if epsilon, err := r.Epsilon(); err != nil || epsilon != 9 {
+		t.Errorf("Epsilon():\nwant %d\ngot  %d (%v)\n", 9, epsilon, err)
+	}
+
+	if got := r.OxygenRating(); got != 23 {
+		t.Errorf("OxygenRating():\nwant %d\ngot  %d\n", 23, got)
+	}
+
+	if got := r.CO2Rating(); got != 10 {
+		t.Errorf("CO2Rating():\nwant %d\ngot  %d\n", 10, got)
+	}
+
+	if got := r.Format(gamma); got != "10110" {
+		t.Errorf("Format():\nwant %s\ngot  %s\n", "10110", got)
+	}
+}
+
+// TestWideValues() checks a report that uses all 64 bits.
+func TestWideValues(t *testing.T) {
+	ones, zeroes := strings.Repeat("1", 64), strings.Repeat("0", 64)
+	r, err := Parse([]string{ones, ones, ones, "0" + ones[1:], zeroes})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gamma, err := r.Gamma(); err != nil || gamma != ^uint64(0) {
+		t.Errorf("Gamma():\nwant %x\ngot  %x (%v)\n", ^uint64(0), gamma, err)
+	}
+
+	if epsilon, _ := r.Epsilon(); epsilon != 0 {
+		t.Errorf("Epsilon():\nwant 0\ngot  %x\n", epsilon)
+	}
+
+	if got := r.OxygenRating(); got != ^uint64(0) {
+		t.Errorf("OxygenRating():\nwant %x\ngot  %x\n", ^uint64(0), got)
+	}
+
+	if got := r.CO2Rating(); got != 0 {
+		t.Errorf("CO2Rating():\nwant 0\ngot  %x\n", got)
+	}
+}
+
+// TestParseErrors() checks the reports that can't be parsed.
+func TestParseErrors(t *testing.T) {
+	for _, lines := range [][]string{
+		nil,
+		{"0101", "011"},
+		{"01a1"},
+		{strings.Repeat("1", 65)},
+	} {
+		if _, err := Parse(lines); err == nil {
+			t.Errorf("Parse(%v): expected an error\n", lines)
+		}
+	}
+
+	r, _ := Parse([]string{"01", "10"})
+	if _, err := r.Gamma(); err == nil {
+		t.Errorf("Gamma(): expected an error for a tie\n")
+	}
+}
@@ -0,0 +1,3 @@
+module sciencerocketry.com/diagnostic
+
+go 1.21
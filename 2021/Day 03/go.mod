@@ -0,0 +1,11 @@
+module day3
+
+go 1.21
+
+require (
+    sciencerocketry.com/diagnostic v0.0.0
+)
+
+replace (
+    sciencerocketry.com/diagnostic => ./diagnostic
+)
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"sciencerocketry.com/explain"
+)
+
+// BingoSquare is a number on a board and whether it has been called.
+type BingoSquare struct {
+	Called bool
+	Value  int
+}
+
+// BingoBoard is a square board of numbers. It keeps a count of the squares called in
+// each row, column and diagonal, so checking for a win after a square is called is
+// O(1) rather than a scan of the whole board.
+type BingoBoard struct {
+	ID     int
+	size   int
+	Values [][]BingoSquare
+
+	rowCalls, columnCalls []int
+	diagonalCalls         int
+	antiDiagonalCalls     int
+	unmarked              int
+	winner                bool
+}
+
+// NewBingoBoard() creates a board from its rows of whitespace-separated numbers. The
+// size of the board is the number of rows, and every row must have that many numbers.
+func NewBingoBoard(id int, lines []string) (*BingoBoard, error) {
+	size := len(lines)
+	if size == 0 {
+		return nil, fmt.Errorf("board %d has no rows", id)
+	}
+
+	board := &BingoBoard{
+		ID:          id,
+		size:        size,
+		Values:      make([][]BingoSquare, size),
+		rowCalls:    make([]int, size),
+		columnCalls: make([]int, size),
+	}
+
+	seen := make(map[int]bool, size*size)
+	for i, line := range lines {
+		values := strings.Fields(line)
+		if len(values) != size {
+			return nil, fmt.Errorf("board %d: row %d has %d numbers, but the board has %d rows", id, i+1, len(values), size)
+		}
+
+		board.Values[i] = make([]BingoSquare, size)
+		for j, value := range values {
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("board %d: row %d has an invalid number '%s'", id, i+1, value)
+			}
+
+			if seen[number] {
+				return nil, fmt.Errorf("board %d: the number %d appears more than once", id, number)
+			}
+
+			seen[number] = true
+			board.Values[i][j].Value = number
+			board.unmarked += number
+		}
+	}
+
+	return board, nil
+}
+
+// Size() returns the number of rows (and columns) on the board.
+func (b *BingoBoard) Size() int {
+	return b.size
+}
+
+// IsWinner() reports whether the board has won.
+func (b *BingoBoard) IsWinner() bool {
+	return b.winner
+}
+
+// call() marks the square at 'row', 'column' as called and reports whether that made
+// the board a winner. Diagonals only count if 'diagonals' is set.
+func (b *BingoBoard) call(row int, column int, diagonals bool) bool {
+	square := &b.Values[row][column]
+	if square.Called {
+		return b.winner
+	}
+
+	square.Called = true
+	b.unmarked -= square.Value
+
+	b.rowCalls[row]++
+	b.columnCalls[column]++
+	if row == column {
+		b.diagonalCalls++
+	}
+
+	if row+column == b.size-1 {
+		b.antiDiagonalCalls++
+	}
+
+	if b.rowCalls[row] == b.size || b.columnCalls[column] == b.size {
+		b.winner = true
+	}
+
+	if diagonals && (b.diagonalCalls == b.size || b.antiDiagonalCalls == b.size) {
+		b.winner = true
+	}
+
+	return b.winner
+}
+
+// reset() clears every mark from the board, ready for a new game.
+func (b *BingoBoard) reset() {
+	for i, row := range b.Values {
+		for j := range row {
+			if row[j].Called {
+				row[j].Called = false
+				b.unmarked += row[j].Value
+			}
+		}
+
+		b.rowCalls[i] = 0
+		b.columnCalls[i] = 0
+	}
+
+	b.diagonalCalls = 0
+	b.antiDiagonalCalls = 0
+	b.winner = false
+}
+
+// CalculateScore() returns the sum of the numbers that haven't been called multiplied
+// by the number that was just called.
+func (b *BingoBoard) CalculateScore(number int) int {
+	return b.unmarked * number
+}
+
+// Print() prints the board, marking the called numbers with a '!'.
+func (b *BingoBoard) Print(w io.Writer) {
+	for i := 0; i < b.size; i++ {
+		for j := 0; j < b.size; j++ {
+			if b.Values[i][j].Called {
+				fmt.Fprintf(w, "%3d!", b.Values[i][j].Value)
+			} else {
+				fmt.Fprintf(w, "%3d ", b.Values[i][j].Value)
+			}
+		}
+
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+// position is the location of a number on one of the boards.
+type position struct {
+	board, row, column int
+}
+
+// Game is a game of bingo between several boards.
+type Game struct {
+	Boards []*BingoBoard
+	// Diagonals allows a board to win by calling every number on one of its diagonals.
+	Diagonals bool
+
+	// index maps each number to the squares it appears in, in board order
+	index map[int][]position
+}
+
+// NewGame() creates a Game and indexes the numbers on the boards.
+func NewGame(boards []*BingoBoard) *Game {
+	g := &Game{Boards: boards, index: make(map[int][]position)}
+
+	for b, board := range boards {
+		for i, row := range board.Values {
+			for j, square := range row {
+				g.index[square.Value] = append(g.index[square.Value], position{board: b, row: i, column: j})
+			}
+		}
+	}
+
+	return g
+}
+
+// Win is a board winning the game.
+type Win struct {
+	Board  *BingoBoard
+	Number int
+	Score  int
+}
+
+// Draw is a single called number and the boards that won because of it.
+type Draw struct {
+	Index  int
+	Number int
+	Wins   []Win
+}
+
+// Log is the replay log of a game - every number drawn and who won on it.
+type Log struct {
+	Draws []Draw
+}
+
+// Play() calls each of the numbers in turn and records the result in a Log. Once a
+// board has won, no more numbers are marked on it. The boards' marks are cleared first,
+// so a Game can be played more than once, but the boards in a Log only show the marks
+// from the latest Play().
+func (g *Game) Play(numbers []int) *Log {
+	for _, board := range g.Boards {
+		board.reset()
+	}
+
+	log := &Log{Draws: make([]Draw, 0, len(numbers))}
+
+	for i, number := range numbers {
+		draw := Draw{Index: i, Number: number}
+
+		for _, p := range g.index[number] {
+			board := g.Boards[p.board]
+			if board.IsWinner() {
+				continue
+			}
+
+			if board.call(p.row, p.column, g.Diagonals) {
+				draw.Wins = append(draw.Wins, Win{Board: board, Number: number, Score: board.CalculateScore(number)})
+			}
+		}
+
+		log.Draws = append(log.Draws, draw)
+	}
+
+	return log
+}
+
+// Wins() returns every win in the order they happened.
+func (l *Log) Wins() []Win {
+	var wins []Win
+	for _, draw := range l.Draws {
+		wins = append(wins, draw.Wins...)
+	}
+
+	return wins
+}
+
+// FirstWin() returns the first board to win. If no board won, ok is false.
+func (l *Log) FirstWin() (win Win, ok bool) {
+	wins := l.Wins()
+	if len(wins) == 0 {
+		return win, false
+	}
+
+	return wins[0], true
+}
+
+// LastWin() returns the last board to win. If no board won, ok is false.
+func (l *Log) LastWin() (win Win, ok bool) {
+	wins := l.Wins()
+	if len(wins) == 0 {
+		return win, false
+	}
+
+	return wins[len(wins)-1], true
+}
+
+// Print() prints the draws that produced a winner.
+func (l *Log) Print(w io.Writer) {
+	for _, draw := range l.Draws {
+		for _, win := range draw.Wins {
+			fmt.Fprintf(w, "Draw %3d (%2d): board %3d wins with a score of %d\n", draw.Index+1, draw.Number, win.Board.ID, win.Score)
+		}
+	}
+}
+
+// Explain() explains the score of each of the boards for a part of the puzzle. The
+// boards that won are explained in the order they won, with a drawing of the one that
+// answers the part, which is described by 'answer' (e.g. 'the first to win'). The
+// boards that never won come last.
+func (l *Log) Explain(e *explain.Explainer, part int, boards []*BingoBoard, answer Win, description string) error {
+	won := map[*BingoBoard]bool{}
+
+	for _, draw := range l.Draws {
+		for _, win := range draw.Wins {
+			won[win.Board] = true
+
+			ex := explain.Explanation{
+				Part:   part,
+				Item:   "board " + strconv.Itoa(win.Board.ID),
+				Value:  win.Score,
+				Reason: fmt.Sprintf("won on draw %d, when %d was called, with %d unmarked", draw.Index+1, draw.Number, win.Board.unmarked),
+			}
+
+			if win.Board == answer.Board {
+				ex.Reason += ", " + description
+				ex.Details = explain.Lines(win.Board.Print)
+			}
+
+			if err := e.Explain(ex); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, board := range boards {
+		if !won[board] {
+			if err := e.Explain(explain.Explanation{Part: part, Item: "board " + strconv.Itoa(board.ID), Reason: "never won"}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
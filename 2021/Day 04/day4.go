@@ -1,194 +1,123 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"io"
 	"log"
 	"os"
 	"strconv"
 	"strings"
-)
-
-const boardSize = 5
 
-type BingoSquare struct {
-	Called bool
-	Value  string
-}
+	"sciencerocketry.com/explain"
+	"sciencerocketry.com/fileprocessing"
+)
 
-type BingoBoard struct {
-	Values [boardSize][boardSize]BingoSquare
-	winner bool
-}
+// main() plays bingo and prints the first and last boards to win. It receives the name
+// of the data file containing the called numbers and the boards. The flags allow
+// diagonal wins and print the replay log of every win. '-explain' explains the score
+// of every board for each part, as text or JSON, on stderr or in the '-explain-to' file:
+//
+//	day4 [-diagonals] [-log] [-explain text|json [-explain-to file]] day4.txt
+func main() {
+	diagonals := flag.Bool("diagonals", false, "allow a board to win by completing a diagonal")
+	showLog := flag.Bool("log", false, "print every board that won and the draw it won on")
+	explainFormat := flag.String("explain", "", "explain the score of every board, as 'text' or 'json'")
+	explainTo := flag.String("explain-to", "", "write the explanations to this `file` instead of stderr")
+	flag.Parse()
 
-func NewBingoBoard(lines []string) *BingoBoard {
-	if len(lines) < boardSize || boardSize <= 0 {
-		// insufficient input or invalid board size
-		return nil
+	explainer, err := explain.Open(*explainFormat, *explainTo)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	board := new(BingoBoard)
-	board.winner = false
+	defer explainer.Close()
 
-	for i := 0; i < boardSize; i++ {
-		values := strings.Fields(lines[i])
-		for j := 0; j < boardSize; j++ {
-			board.Values[i][j].Value = values[j]
-			board.Values[i][j].Called = false
-		}
-	}
+	inputFile := flag.Arg(0)
 
-	return board
-}
-
-func (b *BingoBoard) IsWinner() bool {
-	if b.winner {
-		return true
+	fileContents, err := fileprocessing.ReadFile(inputFile)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	for i := 0; i < boardSize; i++ {
-		rowWins, columnWins := true, true
-		for j := 0; j < boardSize; j++ {
-			// check rows
-			if b.Values[i][j].Called != true {
-				rowWins = false
-			}
-
-			// check columns
-			if b.Values[j][i].Called != true {
-				columnWins = false
-			}
-		}
+	numLines := len(fileContents)
+	if numLines <= 2 {
+		log.Fatal(fmt.Errorf("invalid input data"))
+	}
 
-		if rowWins || columnWins {
-			b.winner = true
-			return true
-		}
+	calledNumbers, boards, err := ParseInput(fileContents)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	return false
-}
+	game := NewGame(boards)
+	game.Diagonals = *diagonals
 
-func (b *BingoBoard) CalculateScore(number string) int {
-	unmarked := 0
-	for i := 0; i < boardSize; i++ {
-		for j := 0; j < boardSize; j++ {
-			value, error := strconv.Atoi(b.Values[i][j].Value)
-			if error != nil {
-				log.Fatal(error.Error())
-			}
-
-			if b.Values[i][j].Called == false {
-				unmarked += value
-			}
-		}
+	replay := game.Play(calledNumbers)
+	if *showLog {
+		replay.Print(os.Stdout)
+		fmt.Printf("\n")
 	}
 
-	winningNumber, error := strconv.Atoi(number)
-	if error != nil {
-		log.Fatal(error.Error())
+	firstWin, ok := replay.FirstWin()
+	if !ok {
+		log.Fatal(fmt.Errorf("none of the boards won"))
 	}
 
-	return unmarked * winningNumber
-}
-
-func (b *BingoBoard) SetCalled(value string) bool {
-	for i := 0; i < boardSize; i++ {
-		for j := 0; j < boardSize; j++ {
-			if b.Values[i][j].Value == value {
-				b.Values[i][j].Called = true
-				return true
-			}
-		}
-	}
+	fmt.Printf("First winning board: \n")
+	firstWin.Board.Print(os.Stdout)
+	fmt.Printf("Last Number: %d, SCORE: %d\n\n", firstWin.Number, firstWin.Score)
 
-	return false
-}
+	lastWin, _ := replay.LastWin()
 
-func (b *BingoBoard) Clear() {
-	for i := 0; i < boardSize; i++ {
-		for j := 0; j < boardSize; j++ {
-			b.Values[i][j].Value = ""
-			b.Values[i][j].Called = false
-		}
+	if err := replay.Explain(explainer, 1, boards, firstWin, "the first to win"); err != nil {
+		log.Fatal(err)
 	}
-}
-
-func (b *BingoBoard) Print(w io.Writer) {
-	for i := 0; i < boardSize; i++ {
-		for j := 0; j < boardSize; j++ {
-			if b.Values[i][j].Called {
-				fmt.Fprintf(w, "%3s!", b.Values[i][j].Value)
-			} else {
-				fmt.Fprintf(w, "%3s ", b.Values[i][j].Value)
-			}
-		}
 
-		fmt.Fprintf(w, "\n")
+	if err := replay.Explain(explainer, 2, boards, lastWin, "the last to win"); err != nil {
+		log.Fatal(err)
 	}
 
+	fmt.Printf("Last winning board: \n")
+	lastWin.Board.Print(os.Stdout)
+	fmt.Printf("Last Number: %d, SCORE: %d\n\n", lastWin.Number, lastWin.Score)
 }
 
-func main() {
-	var inputFile string
-	inputFile = os.Args[1]
-
-	fileContents, err := ReadFile(inputFile)
-	if err != nil {
-		log.Fatal(err)
+// ParseInput() parses the comma-separated called numbers on the first line and the
+// boards that follow, each of which is preceded by a blank line.
+func ParseInput(input []string) ([]int, []*BingoBoard, error) {
+	sections := fileprocessing.Sections(input)
+	if len(sections) == 0 {
+		return nil, nil, fmt.Errorf("there are no called numbers")
 	}
 
-	numLines := len(fileContents)
-	if numLines <= 2 {
-		log.Fatal(fmt.Errorf("invalid input data"))
+	numbers := sections[0]
+	if len(numbers.Lines) != 1 {
+		return nil, nil, numbers.Errorf(1, "expected a blank line after the called numbers")
 	}
 
-	numBoards := int((numLines - 1) / (int(boardSize) + 1))
-	if numBoards <= 0 {
-		log.Fatal(fmt.Errorf("no board data"))
-	}
+	var calledNumbers []int
+	for _, value := range strings.Split(numbers.Lines[0], ",") {
+		number, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, nil, numbers.Errorf(0, "invalid called number '%s'", value)
+		}
 
-	calledNumbers := strings.Split(fileContents[0], ",")
-	if len(calledNumbers) <= 0 {
-		log.Fatal(fmt.Errorf("no called numbers data"))
+		calledNumbers = append(calledNumbers, number)
 	}
 
 	var boards []*BingoBoard
+	for _, s := range sections[1:] {
+		board, err := NewBingoBoard(len(boards)+1, s.Lines)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", s.Start(), err)
+		}
 
-	// import board data
-	for i := 0; i < numBoards; i++ {
-		startIndex := 2 + (boardSize+1)*i // first two lines are the called list and an empty line - each board ends with an empty line
-		boardInput := fileContents[startIndex : startIndex+boardSize]
-
-		boards = append(boards, NewBingoBoard(boardInput))
+		boards = append(boards, board)
 	}
 
-	var firstWinningBoard, lastWinningBoard *BingoBoard
-	var firstWinningNumber, lastWinningNumber string
-
-	for _, calledNumber := range calledNumbers {
-		for _, board := range boards {
-			if !board.IsWinner() {
-				board.SetCalled(calledNumber)
-
-				if board.IsWinner() {
-					lastWinningBoard = board
-					lastWinningNumber = calledNumber
-
-					if firstWinningBoard == nil {
-						firstWinningBoard = board
-						firstWinningNumber = calledNumber
-					}
-				}
-			}
-		}
+	if len(boards) == 0 {
+		return nil, nil, fmt.Errorf("there are no boards")
 	}
 
-	fmt.Printf("First winning board: \n")
-	firstWinningBoard.Print(os.Stdout)
-	fmt.Printf("Last Number: %s, SCORE: %d\n\n", firstWinningNumber, firstWinningBoard.CalculateScore(firstWinningNumber))
-
-	fmt.Printf("Last winning board: \n")
-	lastWinningBoard.Print(os.Stdout)
-	fmt.Printf("Last Number: %s, SCORE: %d\n\n", lastWinningNumber, lastWinningBoard.CalculateScore(lastWinningNumber))
+	return calledNumbers, boards, nil
 }
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"sciencerocketry.com/explain"
+	"sciencerocketry.com/fileprocessing"
+)
+
+// playFile() plays the game in the specified file.
+func playFile(t *testing.T, filename string, diagonals bool) *Log {
+	lines, err := fileprocessing.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	numbers, boards, err := ParseInput(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := NewGame(boards)
+	g.Diagonals = diagonals
+
+	return g.Play(numbers)
+}
+
+// TestPlay() checks the first and last winners for the puzzle's example.
+func TestPlay(t *testing.T) {
+	replay := playFile(t, "day4sample.txt", false)
+
+	first, ok := replay.FirstWin()
+	if !ok || first.Board.ID != 3 || first.Number != 24 || first.Score != 4512 {
+		t.Errorf("FirstWin():\nwant board 3, number 24, score 4512\ngot  %+v\n", first)
+	}
+
+	last, ok := replay.LastWin()
+	if !ok || last.Board.ID != 2 || last.Number != 13 || last.Score != 1924 {
+		t.Errorf("LastWin():\nwant board 2, number 13, score 1924\ngot  %+v\n", last)
+	}
+
+	if wins := replay.Wins(); len(wins) != 3 {
+		t.Errorf("Wins():\nwant 3 wins\ngot  %d\n", len(wins))
+	}
+}
+
+// TestDiagonals() checks that a diagonal only wins when diagonals are allowed.
+func TestDiagonals(t *testing.T) {
+	for _, diagonals := range []bool{false, true} {
+		board, err := NewBingoBoard(1, []string{"1 2 3", "4 5 6", "7 8 9"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		g := NewGame([]*BingoBoard{board})
+		g.Diagonals = diagonals
+
+		_, won := g.Play([]int{3, 5, 7}).FirstWin()
+		if won != diagonals {
+			t.Errorf("Play() with diagonals %t:\nwant a win %t\ngot  %t\n", diagonals, diagonals, won)
+		}
+	}
+}
+
+// TestPlayAgain() checks that playing a game a second time gives the same result.
+func TestPlayAgain(t *testing.T) {
+	board, err := NewBingoBoard(1, []string{"1 2", "3 4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := NewGame([]*BingoBoard{board})
+	for i := 1; i <= 2; i++ {
+		win, ok := g.Play([]int{1, 2}).FirstWin()
+		if !ok || win.Number != 2 || win.Score != 14 {
+			t.Errorf("Play() %d:\nwant a win on 2 with a score of 14\ngot  %+v (%t)\n", i, win, ok)
+		}
+	}
+}
+
+// TestNewBingoBoard() checks the board size comes from the input and bad boards are
+// rejected.
+func TestNewBingoBoard(t *testing.T) {
+	board, err := NewBingoBoard(1, []string{"1 2", "3 4"})
+	if err != nil || board.Size() != 2 {
+		t.Errorf("NewBingoBoard():\nwant a 2x2 board\ngot  %v (%v)\n", board, err)
+	}
+
+	for _, rows := range [][]string{
+		{"1 2 3", "4 5 6"},
+		{"1 2", "3 x"},
+		{"1 2", "3 1"},
+	} {
+		if _, err := NewBingoBoard(1, rows); err == nil {
+			t.Errorf("NewBingoBoard(%v): expected an error\n", rows)
+		}
+	}
+}
+
+// TestParseInput() checks CRLF line endings and extra blank lines between and after the
+// boards, and that errors give the line the bad board starts on.
+func TestParseInput(t *testing.T) {
+	input := "7,4,9\r\n\r\n\r\n1 2\r\n3 4\r\n\r\n5 6\r\n7 8\r\n\r\n\r\n"
+
+	numbers, boards, err := ParseInput(strings.Split(input, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(numbers) != 3 || len(boards) != 2 || boards[1].ID != 2 || boards[1].Values[1][1].Value != 8 {
+		t.Errorf("ParseInput():\nwant 3 numbers and 2 boards\ngot  %v %d\n", numbers, len(boards))
+	}
+
+	_, _, err = ParseInput([]string{"1,2", "", "1 2", "3 4", "", "", "5 6", "7"})
+	if err == nil || !strings.HasPrefix(err.Error(), "line 7:") {
+		t.Errorf("ParseInput():\nwant an error on line 7\ngot  %v\n", err)
+	}
+}
+
+// TestExplain() checks the explanations of the example's boards for part 2.
+func TestExplain(t *testing.T) {
+	lines, err := fileprocessing.ReadFile("day4sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	numbers, boards, err := ParseInput(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replay := NewGame(boards).Play(numbers)
+	last, _ := replay.LastWin()
+
+	var output strings.Builder
+	e, err := explain.New(&output, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := replay.Explain(e, 2, boards, last, "the last to win"); err != nil {
+		t.Fatal(err)
+	}
+
+	var explanations []explain.Explanation
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		var ex explain.Explanation
+		if err := json.Unmarshal([]byte(line), &ex); err != nil {
+			t.Fatal(err)
+		}
+
+		explanations = append(explanations, ex)
+	}
+
+	if len(explanations) != 3 {
+		t.Fatalf("Explain():\nwant 3 boards\ngot  %d\n", len(explanations))
+	}
+
+	ex := explanations[2]
+	if ex.Part != 2 || ex.Item != "board 2" || ex.Value != 1924 || len(ex.Details) != 5 ||
+		ex.Reason != "won on draw 15, when 13 was called, with 148 unmarked, the last to win" {
+		t.Errorf("Explain() board 2:\nwant a score of 1924 and a drawing of the board\ngot  %+v\n", ex)
+	}
+
+	if len(explanations[0].Details) != 0 {
+		t.Errorf("Explain() board 3:\nwant no drawing\ngot  %q\n", explanations[0].Details)
+	}
+}
@@ -0,0 +1,13 @@
+module day4
+
+go 1.21
+
+require (
+    sciencerocketry.com/explain v0.0.0
+    sciencerocketry.com/fileprocessing v0.0.0
+)
+
+replace (
+    sciencerocketry.com/explain => ../explain
+    sciencerocketry.com/fileprocessing => ../fileprocessing
+)
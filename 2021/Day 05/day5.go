@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -9,44 +10,107 @@ import (
 	"strings"
 )
 
+// Point is a position on the ocean floor.
+type Point struct {
+	X, Y int
+}
+
+// Line is a line of hydrothermal vents running from (x1,y1) to (x2,y2) inclusive.
 type Line struct {
 	x1, y1 int
 	x2, y2 int
-	sVal   string
 }
 
-func NewLine(data string) *Line {
-	if len(data) <= 0 {
-		// insufficient input
-		return nil
-	}
-
-	parsedData := strings.Fields(data)
-	if len(parsedData) != 3 {
-		//invalid input
-		return nil
+// NewLine() parses a line of vents such as '0,9 -> 5,9'.
+func NewLine(data string) (*Line, error) {
+	start, end, found := strings.Cut(data, "->")
+	if !found {
+		return nil, fmt.Errorf("'%s' isn't a line - expected 'x1,y1 -> x2,y2'", data)
 	}
 
 	var err error
 
 	line := new(Line)
-	line.x1, line.y1, err = parseCoordinates(parsedData[0])
+	line.x1, line.y1, err = parseCoordinates(strings.TrimSpace(start))
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
-	line.x2, line.y2, err = parseCoordinates(parsedData[2])
+	line.x2, line.y2, err = parseCoordinates(strings.TrimSpace(end))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// ParseLines() parses each line of the input into a Line, ignoring blank lines. Errors
+// include the number of the line that couldn't be parsed.
+func ParseLines(input []string) ([]*Line, error) {
+	var lines []*Line
+
+	for i, data := range input {
+		if len(strings.TrimSpace(data)) == 0 {
+			continue
+		}
+
+		line, err := NewLine(data)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		lines = append(lines, line)
 	}
 
-	return line
+	return lines, nil
 }
 
 func (l *Line) Print(w io.Writer) {
 	fmt.Fprintf(w, "(%d,%d) -> (%d, %d)\n", l.x1, l.y1, l.x2, l.y2)
 }
 
+// IsAxisAligned() reports whether the line is horizontal or vertical.
+func (l *Line) IsAxisAligned() bool {
+	return l.x1 == l.x2 || l.y1 == l.y2
+}
+
+// IsDiagonal() reports whether the line is at exactly 45 degrees.
+func (l *Line) IsDiagonal() bool {
+	return getAbsVal(l.x1-l.x2) == getAbsVal(l.y1-l.y2)
+}
+
+// Points() returns the points covered by the line, from (x1,y1) to (x2,y2). It uses
+// Bresenham's algorithm, so the line can be at any angle - horizontal, vertical and 45
+// degree lines cover exactly the points on them.
+func (l *Line) Points() []Point {
+	dx, dy := getAbsVal(l.x2-l.x1), -getAbsVal(l.y2-l.y1)
+	sx, sy := getSign(l.x2-l.x1), getSign(l.y2-l.y1)
+
+	points := make([]Point, 0, max(dx, -dy)+1)
+
+	x, y := l.x1, l.y1
+	err := dx + dy
+	for {
+		points = append(points, Point{X: x, Y: y})
+		if x == l.x2 && y == l.y2 {
+			break
+		}
+
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+
+	return points
+}
+
 func parseCoordinates(coordinates string) (x int, y int, err error) {
 	data := strings.Split(coordinates, ",")
 	if len(data) != 2 {
@@ -54,22 +118,31 @@ func parseCoordinates(coordinates string) (x int, y int, err error) {
 		return -1, -1, fmt.Errorf("could not parse the provided coordinates: %s", coordinates)
 	}
 
-	x, err = strconv.Atoi(data[0])
+	x, err = strconv.Atoi(strings.TrimSpace(data[0]))
 	if err != nil {
-		return -1, -1, err
+		return -1, -1, fmt.Errorf("the x coordinate of '%s' isn't a number", coordinates)
 	}
 
-	y, err = strconv.Atoi(data[1])
+	y, err = strconv.Atoi(strings.TrimSpace(data[1]))
 	if err != nil {
-		return -1, -1, err
+		return -1, -1, fmt.Errorf("the y coordinate of '%s' isn't a number", coordinates)
 	}
 
 	return x, y, nil
 }
 
+// main() prints the number of points where lines of vents overlap. It receives the name
+// of the data file containing the lines. The flags include lines at any angle and
+// export the overlap density as a heatmap:
+//
+//	day5 [-all] [-pgm heatmap.pgm] [-png heatmap.png] day5.txt
 func main() {
-	var inputFile string
-	inputFile = os.Args[1]
+	all := flag.Bool("all", false, "also count lines that are at angles other than 45 degrees")
+	pgmFile := flag.String("pgm", "", "write the part two density map to this PGM file")
+	pngFile := flag.String("png", "", "write the part two density map to this PNG file")
+	flag.Parse()
+
+	inputFile := flag.Arg(0)
 
 	fileContents, err := ReadFile(inputFile)
 	if err != nil {
@@ -82,65 +155,49 @@ func main() {
 		log.Fatal(fmt.Errorf("invalid input in %s\n", inputFile))
 	}
 
-	var lines []*Line
-
-	// import Line data
-	for i := 0; i < numLines; i++ {
-		lines = append(lines, NewLine(fileContents[i]))
+	lines, err := ParseLines(fileContents)
+	if err != nil {
+		log.Fatal(fmt.Errorf("%s: %w", inputFile, err))
 	}
 
-	fmt.Printf("Number of Lines: %d\n", numLines)
+	fmt.Printf("Number of Lines: %d\n", len(lines))
 
 	part1 := Day5PartOne(lines)
 	fmt.Printf("Number of Overlaps (part 1): %d\n", part1)
 
-	part2 := Day5PartTwo(lines)
-	fmt.Printf("Number of Overlaps (part 2): %d\n", part2)
-}
+	part2 := Rasterise(lines, AxisAlignedOrDiagonal)
+	fmt.Printf("Number of Overlaps (part 2): %d\n", part2.Overlaps())
 
-func getSmallerLarger(one int, two int) (smaller int, larger int) {
-	if one < two {
-		return one, two
-	} else {
-		return two, one
+	if *all {
+		fmt.Printf("Number of Overlaps (all lines): %d\n", Rasterise(lines, AllLines).Overlaps())
 	}
-}
 
-func Day5PartOne(lines []*Line) int {
-	grid := make(map[string]int)
-
-	for _, line := range lines {
-		if line.x1 == line.x2 {
-			// horizontal line
-			smaller, larger := getSmallerLarger(line.y1, line.y2)
-			for i := smaller; i <= larger; i++ {
-				mapIndex := strconv.Itoa(line.x1) + "," + strconv.Itoa(i)
-				grid[mapIndex]++
-			}
-
-			continue
+	if len(*pgmFile) > 0 {
+		if err := writeFile(*pgmFile, part2.WritePGM); err != nil {
+			log.Fatal(err)
 		}
+	}
 
-		if line.y1 == line.y2 {
-			// vertical line
-			smaller, larger := getSmallerLarger(line.x1, line.x2)
-			for i := smaller; i <= larger; i++ {
-				mapIndex := strconv.Itoa(i) + "," + strconv.Itoa(line.y1)
-				grid[mapIndex]++
-			}
-
-			continue
+	if len(*pngFile) > 0 {
+		if err := writeFile(*pngFile, part2.WritePNG); err != nil {
+			log.Fatal(err)
 		}
 	}
+}
 
-	countOverlaps := 0
-	for _, val := range grid {
-		if val > 1 {
-			countOverlaps++
-		}
+// writeFile() creates the named file and writes to it with 'write'.
+func writeFile(filename string, write func(io.Writer) error) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := write(file); err != nil {
+		file.Close()
+		return err
 	}
 
-	return countOverlaps
+	return file.Close()
 }
 
 func getAbsVal(input int) int {
@@ -151,62 +208,24 @@ func getAbsVal(input int) int {
 	return input
 }
 
-func Day5PartTwo(lines []*Line) int {
-	grid := make(map[string]int)
-
-	for _, line := range lines {
-		if line.x1 == line.x2 {
-			// horizontal line
-			smaller, larger := getSmallerLarger(line.y1, line.y2)
-			for i := smaller; i <= larger; i++ {
-				mapIndex := strconv.Itoa(line.x1) + "," + strconv.Itoa(i)
-				grid[mapIndex]++
-			}
-
-			continue
-		}
-
-		if line.y1 == line.y2 {
-			// vertical line
-			smaller, larger := getSmallerLarger(line.x1, line.x2)
-			for i := smaller; i <= larger; i++ {
-				mapIndex := strconv.Itoa(i) + "," + strconv.Itoa(line.y1)
-				grid[mapIndex]++
-			}
-
-			continue
-		}
-
-		if (getAbsVal(line.x1 - line.x2)) == (getAbsVal(line.y1 - line.y2)) {
-			// diagonal line
-			lineLength := getAbsVal(line.x1-line.x2) + 1
-
-			xModifier, yModifier := 1, 1
-			if line.x1 > line.x2 {
-				xModifier = -1
-			}
-
-			if line.y1 > line.y2 {
-				yModifier = -1
-			}
-
-			for i := 0; i < lineLength; i++ {
-				x := line.x1 + int(xModifier*i)
-				y := line.y1 + int(yModifier*i)
-				mapIndex := strconv.Itoa(x) + "," + strconv.Itoa(y)
-				grid[mapIndex]++
-			}
-
-			continue
-		}
+func getSign(input int) int {
+	switch {
+	case input < 0:
+		return -1
+	case input > 0:
+		return 1
 	}
 
-	countOverlaps := 0
-	for _, val := range grid {
-		if val > 1 {
-			countOverlaps++
-		}
-	}
+	return 0
+}
 
-	return countOverlaps
+// Day5PartOne() counts the points where at least two horizontal or vertical lines overlap.
+func Day5PartOne(lines []*Line) int {
+	return Rasterise(lines, AxisAligned).Overlaps()
+}
+
+// Day5PartTwo() counts the points where at least two horizontal, vertical or 45 degree
+// lines overlap.
+func Day5PartTwo(lines []*Line) int {
+	return Rasterise(lines, AxisAlignedOrDiagonal).Overlaps()
 }
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestOverlaps() checks both parts of the puzzle for the example input.
+func TestOverlaps(t *testing.T) {
+	input, err := ReadFile("day5sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := ParseLines(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := Day5PartOne(lines); got != 5 {
+		t.Errorf("Day5PartOne():\nwant %d\ngot  %d\n", 5, got)
+	}
+
+	if got := Day5PartTwo(lines); got != 12 {
+		t.Errorf("Day5PartTwo():\nwant %d\ngot  %d\n", 12, got)
+	}
+}
+
+var pointsTests = []struct {
+	line string
+	want []Point
+}{
+	{"1,1 -> 1,3", []Point{{1, 1}, {1, 2}, {1, 3}}},
+	{"9,7 -> 7,9", []Point{{9, 7}, {8, 8}, {7, 9}}},
+	{"0,0 -> 4,2", []Point{{0, 0}, {1, 1}, {2, 1}, {3, 2}, {4, 2}}},
+	{"0,0 -> 1,3", []Point{{0, 0}, {0, 1}, {1, 2}, {1, 3}}},
+	{"5,5 -> 5,5", []Point{{5, 5}}},
+}
+
+// TestPoints() checks the points covered by lines at various angles.
+func TestPoints(t *testing.T) {
+	for _, test := range pointsTests {
+		l, err := NewLine(test.line)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := l.Points(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Points(%s):\nwant %v\ngot  %v\n", test.line, test.want, got)
+		}
+	}
+}
+
+// TestParseErrors() checks that parse errors give the number of the bad line.
+func TestParseErrors(t *testing.T) {
+	_, err := ParseLines([]string{"0,9 -> 5,9", "", "8,0 -> 0,x"})
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3:") {
+		t.Errorf("ParseLines():\nwant an error for line 3\ngot  %v\n", err)
+	}
+}
+
+// TestExport() checks the size of the exported heatmaps.
+func TestExport(t *testing.T) {
+	l1, _ := NewLine("0,0 -> 3,0")
+	l2, _ := NewLine("1,0 -> 1,1")
+	d := Rasterise([]*Line{l1, l2}, AllLines)
+
+	if d.At(1, 0) != 2 || d.MaxDensity() != 2 || d.Overlaps() != 1 {
+		t.Errorf("Rasterise():\nwant a single overlap at 1,0\ngot  %v\n", d.counts)
+	}
+
+	var pgm bytes.Buffer
+	if err := d.WritePGM(&pgm); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "P5\n4 2\n255\n\x7f\xff\x7f\x7f\x00\x7f\x00\x00"
+	if pgm.String() != want {
+		t.Errorf("WritePGM():\nwant %q\ngot  %q\n", want, pgm.String())
+	}
+
+	var buf bytes.Buffer
+	if err := d.WritePNG(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil || img.Bounds().Dx() != 4 || img.Bounds().Dy() != 2 {
+		t.Errorf("WritePNG():\nwant a 4x2 image\ngot  %v (%v)\n", img.Bounds(), err)
+	}
+}
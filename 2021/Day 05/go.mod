@@ -0,0 +1,3 @@
+module day5
+
+go 1.21
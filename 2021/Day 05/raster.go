@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// LineFilter selects which lines are drawn by Rasterise().
+type LineFilter func(l *Line) bool
+
+// AxisAligned selects horizontal and vertical lines.
+func AxisAligned(l *Line) bool {
+	return l.IsAxisAligned()
+}
+
+// AxisAlignedOrDiagonal selects horizontal, vertical and 45 degree lines.
+func AxisAlignedOrDiagonal(l *Line) bool {
+	return l.IsAxisAligned() || l.IsDiagonal()
+}
+
+// AllLines selects every line, whatever its angle.
+func AllLines(l *Line) bool {
+	return true
+}
+
+// DensityMap holds the number of lines covering each point in the rectangle from
+// Origin that is Width by Height points.
+type DensityMap struct {
+	Origin        Point
+	Width, Height int
+	counts        []int
+}
+
+// Rasterise() draws each of the lines selected by 'filter' and returns the number of
+// lines covering each point.
+func Rasterise(lines []*Line, filter LineFilter) *DensityMap {
+	var selected []*Line
+	for _, l := range lines {
+		if filter(l) {
+			selected = append(selected, l)
+		}
+	}
+
+	d := &DensityMap{}
+	if len(selected) == 0 {
+		return d
+	}
+
+	minPoint := Point{X: selected[0].x1, Y: selected[0].y1}
+	maxPoint := minPoint
+	for _, l := range selected {
+		minPoint.X, minPoint.Y = min(minPoint.X, l.x1, l.x2), min(minPoint.Y, l.y1, l.y2)
+		maxPoint.X, maxPoint.Y = max(maxPoint.X, l.x1, l.x2), max(maxPoint.Y, l.y1, l.y2)
+	}
+
+	d.Origin = minPoint
+	d.Width = maxPoint.X - minPoint.X + 1
+	d.Height = maxPoint.Y - minPoint.Y + 1
+	d.counts = make([]int, d.Width*d.Height)
+
+	for _, l := range selected {
+		for _, p := range l.Points() {
+			d.counts[d.index(p.X, p.Y)]++
+		}
+	}
+
+	return d
+}
+
+// index() returns the position of the point (x,y) in the counts.
+func (d *DensityMap) index(x int, y int) int {
+	return (y-d.Origin.Y)*d.Width + (x - d.Origin.X)
+}
+
+// At() returns the number of lines covering the point (x,y).
+func (d *DensityMap) At(x int, y int) int {
+	if x < d.Origin.X || x >= d.Origin.X+d.Width || y < d.Origin.Y || y >= d.Origin.Y+d.Height {
+		return 0
+	}
+
+	return d.counts[d.index(x, y)]
+}
+
+// Overlaps() returns the number of points covered by at least two lines.
+func (d *DensityMap) Overlaps() int {
+	return d.CountAtLeast(2)
+}
+
+// CountAtLeast() returns the number of points covered by at least 'n' lines.
+func (d *DensityMap) CountAtLeast(n int) int {
+	count := 0
+	for _, c := range d.counts {
+		if c >= n {
+			count++
+		}
+	}
+
+	return count
+}
+
+// MaxDensity() returns the largest number of lines covering a single point.
+func (d *DensityMap) MaxDensity() int {
+	maxDensity := 0
+	for _, c := range d.counts {
+		maxDensity = max(maxDensity, c)
+	}
+
+	return maxDensity
+}
+
+// level() scales the number of lines covering a point to 0..255.
+func (d *DensityMap) level(count int, maxDensity int) uint8 {
+	if maxDensity == 0 {
+		return 0
+	}
+
+	return uint8(count * 255 / maxDensity)
+}
+
+// WritePGM() writes the map as a binary greyscale PGM image, where brighter points
+// are covered by more lines.
+func (d *DensityMap) WritePGM(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "P5\n%d %d\n255\n", d.Width, d.Height)
+
+	maxDensity := d.MaxDensity()
+	for _, c := range d.counts {
+		bw.WriteByte(d.level(c, maxDensity))
+	}
+
+	return bw.Flush()
+}
+
+// heat() maps a level of 0..255 onto a black - red - yellow - white colour ramp.
+func heat(level uint8) color.RGBA {
+	v := int(level) * 3
+	r, g, b := min(v, 255), min(max(v-255, 0), 255), min(max(v-510, 0), 255)
+
+	return color.RGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 255}
+}
+
+// WritePNG() writes the map as a PNG heatmap.
+func (d *DensityMap) WritePNG(w io.Writer) error {
+	img := image.NewRGBA(image.Rect(0, 0, d.Width, d.Height))
+
+	maxDensity := d.MaxDensity()
+	for y := 0; y < d.Height; y++ {
+		for x := 0; x < d.Width; x++ {
+			img.SetRGBA(x, y, heat(d.level(d.counts[y*d.Width+x], maxDensity)))
+		}
+	}
+
+	return png.Encode(w, img)
+}
@@ -1,19 +1,34 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
-	"os"
+	"math/big"
 	"strconv"
 	"strings"
+
+	"sciencerocketry.com/bigmatrix"
 )
 
+// the timers described by the puzzle - a fish resets to 6 after it spawns and a
+// newborn fish starts at 8 (two extra days for its first cycle)
 const lanternFishCycle = 6
 const firstCycleAdds = 2
 
+// main() prints the number of lanternfish after each of the specified number of days.
+// It receives the name of the data file containing the comma-separated list of fish
+// timers. The flags allow the timers and the simulation to be configured:
+//
+//	day6 [-days 80,256] [-reset 6] [-newborn 8] [-matrix] day6.txt
 func main() {
-	var inputFile string
-	inputFile = os.Args[1]
+	days := flag.String("days", "80,256", "comma-separated list of the number of days to simulate")
+	resetTimer := flag.Int("reset", lanternFishCycle, "the timer value a fish resets to after it spawns")
+	newbornTimer := flag.Int("newborn", lanternFishCycle+firstCycleAdds, "the timer value a newborn fish starts with")
+	useMatrix := flag.Bool("matrix", false, "use matrix exponentiation (faster for a huge number of days)")
+	flag.Parse()
+
+	inputFile := flag.Arg(0)
 
 	fileContents, err := ReadFile(inputFile)
 	if err != nil {
@@ -26,78 +41,151 @@ func main() {
 		log.Fatal(fmt.Errorf("invalid input in %s\n", inputFile))
 	}
 
-	fish := strings.Split(fileContents[0], ",")
-	var fishInts = []int{}
-	var fishIntsPartTwo = []int{}
+	fish, err := parseFish(fileContents[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s, err := NewSimulator(*resetTimer, *newbornTimer)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *useMatrix {
+		s.Mode = MatrixExponentiation
+	}
+
+	for _, d := range strings.Split(*days, ",") {
+		numDays, err := strconv.Atoi(strings.TrimSpace(d))
+		if err != nil {
+			log.Fatal(fmt.Errorf("invalid number of days: %s", d))
+		}
 
-	for _, fishString := range fish {
-		fishInt, error := strconv.Atoi(fishString)
-		if error != nil {
-			log.Fatal(fmt.Errorf("could not convert input value to string"))
+		numFish, err := s.Count(fish, numDays)
+		if err != nil {
+			log.Fatal(err)
 		}
 
-		fishInts = append(fishInts, fishInt)
+		fmt.Printf("%d days : %s fish\n", numDays, numFish)
 	}
+}
 
-	numDaysPartOne := 80
-	numFish := calculateNumberOfFish(fishInts, numDaysPartOne)
-	fmt.Printf("%d days : %d fish\n", numDaysPartOne, numFish)
+// parseFish() converts the comma-separated list of fish timers into integers.
+func parseFish(input string) ([]int, error) {
+	var fish []int
 
-	for _, fishString := range fish {
-		fishInt, error := strconv.Atoi(fishString)
-		if error != nil {
-			log.Fatal(fmt.Errorf("could not convert input value to string"))
+	for i, fishString := range strings.Split(input, ",") {
+		fishInt, err := strconv.Atoi(strings.TrimSpace(fishString))
+		if err != nil {
+			return nil, fmt.Errorf("could not convert the fish timer at index %d (%s) to an integer", i, fishString)
 		}
 
-		fishIntsPartTwo = append(fishIntsPartTwo, fishInt)
+		fish = append(fish, fishInt)
 	}
 
-	numDaysPartTwo := 256
-	numFish = calculateNumberOfFishFast(fishIntsPartTwo, numDaysPartTwo)
-	fmt.Printf("%d days : %d fish\n", numDaysPartTwo, numFish)
+	return fish, nil
 }
 
-func calculateNumberOfFish(fish []int, days int) int {
-	// this algorithm brute forces the problem by maintaining an array of fish
-	// that will grow exponentially large
-	for i := 0; i < days; i++ {
-		numFish := len(fish)
-		for j := 0; j < numFish; j++ {
-			fish[j]--
-			if fish[j] < 0 {
-				fish[j] = lanternFishCycle
-				var newFish int
-				newFish = lanternFishCycle + firstCycleAdds
-				fish = append(fish, newFish)
-			}
-		}
+// Mode specifies how a Simulator advances the population.
+type Mode int
+
+const (
+	// Iterative advances the population one day at a time, which is O(days).
+	Iterative Mode = iota
+	// MatrixExponentiation raises the daily transition matrix to the number of days by
+	// repeated squaring, which is O(log(days)) and suits a huge number of days.
+	MatrixExponentiation
+)
+
+// Simulator counts a population of lanternfish. Rather than tracking every fish, it
+// keeps a bucket for each timer value holding the number of fish with that timer.
+// The counts are big integers, so the population can grow without overflowing.
+type Simulator struct {
+	ResetTimer   int
+	NewbornTimer int
+	Mode         Mode
+}
+
+// NewSimulator() creates an Iterative Simulator where a fish's timer resets to
+// 'resetTimer' after it spawns and a newborn fish starts at 'newbornTimer'.
+func NewSimulator(resetTimer int, newbornTimer int) (*Simulator, error) {
+	if resetTimer < 0 || newbornTimer < 0 {
+		return nil, fmt.Errorf("the timers can't be negative (reset: %d, newborn: %d)", resetTimer, newbornTimer)
 	}
 
-	return len(fish)
+	return &Simulator{ResetTimer: resetTimer, NewbornTimer: newbornTimer}, nil
 }
 
-func calculateNumberOfFishFast(fish []int, numDays int) int {
-	// this algorithm just keeps an array of the days and each day is assigned a
-	// value representing the number of fish that will reproduce on a given day
-	days := []int{0, 0, 0, 0, 0, 0, 0, 0, 0}
-	for _, aFish := range fish {
-		days[aFish]++
+// numTimers() returns the number of distinct timer values a fish can have.
+func (s *Simulator) numTimers() int {
+	return max(s.ResetTimer, s.NewbornTimer) + 1
+}
+
+// Count() returns the number of fish there will be after the specified number of days
+// given the starting timers in 'fish'. The 'fish' slice isn't modified.
+func (s *Simulator) Count(fish []int, days int) (*big.Int, error) {
+	if days < 0 {
+		return nil, fmt.Errorf("the number of days can't be negative (%d)", days)
+	}
+
+	buckets := make([]*big.Int, s.numTimers())
+	for i := range buckets {
+		buckets[i] = new(big.Int)
+	}
+
+	for i, timer := range fish {
+		if timer < 0 || timer >= len(buckets) {
+			return nil, fmt.Errorf("the fish at index %d has a timer (%d) outside of 0..%d", i, timer, len(buckets)-1)
+		}
+
+		buckets[timer].Add(buckets[timer], big.NewInt(1))
 	}
 
-	for i := 0; i < numDays; i++ {
-		numberReproducing := days[0]
-		for j := 1; j < len(days); j++ {
-			days[j-1] = days[j]
+	if s.Mode == MatrixExponentiation {
+		buckets = s.transition().Power(days).Apply(buckets)
+	} else {
+		for i := 0; i < days; i++ {
+			buckets = s.step(buckets)
 		}
+	}
+
+	numFish := new(big.Int)
+	for _, bucket := range buckets {
+		numFish.Add(numFish, bucket)
+	}
+
+	return numFish, nil
+}
+
+// step() advances the buckets by a single day. Every timer counts down by one and the
+// fish whose timer was at 0 reset and spawn a newborn fish.
+func (s *Simulator) step(buckets []*big.Int) []*big.Int {
+	next := make([]*big.Int, len(buckets))
+	for i := range next {
+		next[i] = new(big.Int)
+	}
 
-		days[lanternFishCycle] += numberReproducing
-		days[lanternFishCycle+firstCycleAdds] = numberReproducing
+	for timer := 1; timer < len(buckets); timer++ {
+		next[timer-1].Set(buckets[timer])
 	}
 
-	numFish := 0
-	for _, cycle := range days {
-		numFish += cycle
+	next[s.ResetTimer].Add(next[s.ResetTimer], buckets[0])
+	next[s.NewbornTimer].Add(next[s.NewbornTimer], buckets[0])
+
+	return next
+}
+
+// transition() builds the matrix that step() applies to the buckets, so that
+// transition().Power(n) advances the buckets by n days at once.
+func (s *Simulator) transition() bigmatrix.Matrix {
+	m := bigmatrix.New(s.numTimers())
+
+	for timer := 1; timer < len(m); timer++ {
+		m[timer-1][timer].SetInt64(1)
 	}
 
-	return numFish
+	m[s.ResetTimer][0].Add(m[s.ResetTimer][0], big.NewInt(1))
+	m[s.NewbornTimer][0].Add(m[s.NewbornTimer][0], big.NewInt(1))
+
+	return m
 }
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestCount() validates the sample population in both simulation modes.
+func TestCount(t *testing.T) {
+	var tests = []struct {
+		days   int
+		result string
+	}{
+		{18, "26"},
+		{80, "5934"},
+		{256, "26984457539"},
+	}
+
+	fish := []int{3, 4, 3, 1, 2}
+
+	for _, mode := range []Mode{Iterative, MatrixExponentiation} {
+		s, err := NewSimulator(lanternFishCycle, lanternFishCycle+firstCycleAdds)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s.Mode = mode
+
+		for _, test := range tests {
+			numFish, err := s.Count(fish, test.days)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if numFish.String() != test.result {
+				t.Errorf("Count(%d) mode %d:\nwant %s\ngot  %s\n", test.days, mode, test.result, numFish)
+			}
+		}
+	}
+
+	if fish[0] != 3 || len(fish) != 5 {
+		t.Errorf("Count() modified the input: %v\n", fish)
+	}
+}
+
+// TestCountCustomTimers() compares the simulator against a brute force simulation of
+// every individual fish using timers other than the puzzle's.
+func TestCountCustomTimers(t *testing.T) {
+	var tests = []struct {
+		reset, newborn int
+		fish           []int
+	}{
+		{2, 4, []int{0, 1, 4}},
+		{5, 3, []int{5, 0, 2, 3}},
+		{0, 1, []int{1}},
+	}
+
+	for _, test := range tests {
+		s, err := NewSimulator(test.reset, test.newborn)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for days := 0; days <= 20; days++ {
+			want := bruteForce(test.fish, days, test.reset, test.newborn)
+
+			s.Mode = Iterative
+			iterative, err := s.Count(test.fish, days)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s.Mode = MatrixExponentiation
+			matrix, err := s.Count(test.fish, days)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if iterative.Int64() != int64(want) || matrix.Int64() != int64(want) {
+				t.Errorf("Count(%d) reset %d, newborn %d:\nwant %d\ngot  %s (iterative), %s (matrix)\n", days, test.reset, test.newborn, want, iterative, matrix)
+			}
+		}
+	}
+}
+
+// bruteForce() simulates every individual fish.
+func bruteForce(initial []int, days int, reset int, newborn int) int {
+	fish := append([]int(nil), initial...)
+	for i := 0; i < days; i++ {
+		numFish := len(fish)
+		for j := 0; j < numFish; j++ {
+			fish[j]--
+			if fish[j] < 0 {
+				fish[j] = reset
+				fish = append(fish, newborn)
+			}
+		}
+	}
+
+	return len(fish)
+}
+
+// TestCountHugeNumberOfDays() checks that both modes agree well past the point where
+// the population overflows a 64-bit integer.
+func TestCountHugeNumberOfDays(t *testing.T) {
+	s, err := NewSimulator(lanternFishCycle, lanternFishCycle+firstCycleAdds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fish := []int{3, 4, 3, 1, 2}
+
+	iterative, err := s.Count(fish, 5000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Mode = MatrixExponentiation
+	matrix, err := s.Count(fish, 5000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if iterative.Cmp(matrix) != 0 || iterative.Cmp(big.NewInt(1).Lsh(big.NewInt(1), 64)) <= 0 {
+		t.Errorf("Count(5000):\niterative %s\nmatrix    %s\n", iterative, matrix)
+	}
+}
+
+// TestCountInvalidTimer() checks that a timer outside the simulator's range is rejected.
+func TestCountInvalidTimer(t *testing.T) {
+	s, err := NewSimulator(lanternFishCycle, lanternFishCycle+firstCycleAdds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Count([]int{3, 9}, 1); err == nil {
+		t.Errorf("Count(): expected an error for a timer of 9\n")
+	}
+}
@@ -0,0 +1,11 @@
+module day6
+
+go 1.21
+
+require (
+    sciencerocketry.com/bigmatrix v0.0.0
+)
+
+replace (
+    sciencerocketry.com/bigmatrix => ../bigmatrix
+)
@@ -1,17 +1,36 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"sort"
 	"strconv"
 	"strings"
 )
 
+// main() prints the cheapest position for the crabs to align on and the fuel it takes
+// for each part of the puzzle. It receives the name of the data file containing the
+// crab positions:
+//
+//	day7 [-method ternary|scan] [-curve curve.csv] day7.txt
+//
+// -curve writes the part two cost of every position for plotting.
 func main() {
-	var inputFile string
-	inputFile = os.Args[1]
+	methodName := flag.String("method", "ternary", "how to find the cheapest position: ternary or scan")
+	curveFile := flag.String("curve", "", "write the part two cost curve to this CSV file")
+	flag.Parse()
+
+	method := TernarySearch
+	switch *methodName {
+	case "ternary":
+	case "scan":
+		method = ExactScan
+	default:
+		log.Fatal(fmt.Errorf("unknown method '%s'", *methodName))
+	}
+
+	inputFile := flag.Arg(0)
 
 	fileContents, err := ReadFile(inputFile)
 	if err != nil {
@@ -30,7 +49,7 @@ func main() {
 	numInputs := len(crabPositionInputs)
 
 	for i := 0; i < numInputs; i++ {
-		value, err := strconv.Atoi(crabPositionInputs[i])
+		value, err := strconv.Atoi(strings.TrimSpace(crabPositionInputs[i]))
 		if err != nil {
 			log.Fatal(fmt.Errorf("There was an error converting a position input to a numeric value at index %d\n", i))
 		}
@@ -38,87 +57,31 @@ func main() {
 		crabPositions = append(crabPositions, value)
 	}
 
-	fuelPartOne := minimizeFuelExpense(crabPositions, true)
-	fmt.Printf("Part One - fuel expense: %d\n", fuelPartOne)
-
-	fuelPartTwo := minimizeFuelExpense(crabPositions, false)
-	fmt.Printf("Part Two - fuel expense: %d\n", fuelPartTwo)
-}
-
-func minimizeFuelExpense(positions []int, constantBurn bool) int {
-	currentPosition := calculateMedian(positions)
-
-	multiplier := 0
-
-	minExpense := calculateDistanceSum(positions, currentPosition, constantBurn)
-	minExpensePlus := calculateDistanceSum(positions, currentPosition+1, constantBurn)
-
-	if minExpensePlus <= minExpense {
-		multiplier = 1
-		minExpense = minExpensePlus
+	o, err := NewOptimiser(crabPositions)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	if multiplier != 1 {
-		minExpenseMinus := calculateDistanceSum(positions, currentPosition-1, constantBurn)
-		if minExpenseMinus <= minExpense {
-			multiplier = -1
-			minExpense = minExpenseMinus
-		}
-	}
+	partOne := o.Optimise(ConstantBurn, method)
+	fmt.Printf("Part One - position: %d, fuel expense: %d\n", partOne.Position, partOne.Fuel)
 
-	if multiplier == 0 {
-		// minExpense is the least expensive position
-		return minExpense
-	}
+	partTwo := o.Optimise(IncreasingBurn, method)
+	fmt.Printf("Part Two - position: %d, fuel expense: %d\n", partTwo.Position, partTwo.Fuel)
 
-	i := 2
-	for {
-		// multiplier determines whether we checking smaller or larger positions from the original
-		// position
-		index := currentPosition + (multiplier * i)
-		expense := calculateDistanceSum(positions, index, constantBurn)
-
-		if expense > minExpense {
-			break
+	if len(*curveFile) > 0 {
+		file, err := os.Create(*curveFile)
+		if err != nil {
+			log.Fatal(err)
 		}
 
-		minExpense = expense
-
-		i++
-	}
-
-	return minExpense
-}
-
-func calculateDistanceSum(nums []int, position int, constantBurn bool) int {
-	sum := 0
-	for _, value := range nums {
-
-		if constantBurn {
-			// fuel burn is constant
-			sum += calculateAbsValue(value - position)
-		} else {
-			// fuel burn isn't constant
-			diff := calculateAbsValue(value - position)
-			for i := 0; i < diff; i++ {
-				sum += i + 1
-			}
+		if err := o.WriteCurve(file, IncreasingBurn); err != nil {
+			log.Fatal(err)
 		}
-	}
-
-	return sum
-}
 
-func calculateMedian(nums []int) int {
-	sort.Ints(nums)
-	middleNum := len(nums) / 2
-	if (middleNum % 2) == 1 {
-		// nums length is odd - return the middle number
-		return nums[middleNum]
+		if err := file.Close(); err != nil {
+			log.Fatal(err)
+		}
 	}
-
-	// nums length is even - return the average of the middle two
-	return (nums[(middleNum)-1] + nums[middleNum]) / 2
 }
 
 func calculateAbsValue(num int) int {
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+)
+
+var sample = []int{16, 1, 2, 0, 4, 2, 7, 1, 2, 14}
+
+var optimiseTests = []struct {
+	cost Cost
+	want Result
+}{
+	{ConstantBurn, Result{Position: 2, Fuel: 37}},
+	{IncreasingBurn, Result{Position: 5, Fuel: 168}},
+	{CustomCost("squared", func(d int) int { return d * d }), Result{Position: 5, Fuel: 291}},
+}
+
+// TestOptimise() checks that both methods find the puzzle's answers for the example.
+func TestOptimise(t *testing.T) {
+	o, err := NewOptimiser(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range optimiseTests {
+		for _, method := range []Method{TernarySearch, ExactScan} {
+			if got := o.Optimise(test.cost, method); got != test.want {
+				t.Errorf("Optimise(%s, %d):\nwant %+v\ngot  %+v\n", test.cost.Name, method, test.want, got)
+			}
+		}
+	}
+}
+
+// TestOptimisePlateau() checks that both methods return the lowest of several equally
+// cheap positions.
+func TestOptimisePlateau(t *testing.T) {
+	o, err := NewOptimiser([]int{0, 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Result{Position: 0, Fuel: 10}
+	for _, method := range []Method{TernarySearch, ExactScan} {
+		if got := o.Optimise(ConstantBurn, method); got != want {
+			t.Errorf("Optimise(%s, %d):\nwant %+v\ngot  %+v\n", ConstantBurn.Name, method, want, got)
+		}
+	}
+}
+
+// TestCost() checks the prefix sum totals against adding up the cost of each crab,
+// including for positions outside the range of the crabs.
+func TestCost(t *testing.T) {
+	o, err := NewOptimiser(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, cost := range []Cost{ConstantBurn, IncreasingBurn, PolynomialCost("cubic-ish", 3, 2, 1)} {
+		custom := CustomCost(cost.Name, cost.Fuel)
+		for p := -5; p <= 25; p++ {
+			if got, want := o.Cost(cost, p), o.Cost(custom, p); got != want {
+				t.Errorf("Cost(%s, %d):\nwant %d\ngot  %d\n", cost.Name, p, want, got)
+			}
+		}
+	}
+
+	curve := o.Curve(ConstantBurn)
+	if len(curve) != 17 || curve[2] != 37 || curve[1] != 41 {
+		t.Errorf("Curve():\nwant 17 positions with costs 41 at 1 and 37 at 2\ngot  %v\n", curve)
+	}
+
+	if sample[0] != 16 {
+		t.Errorf("NewOptimiser(): the positions were modified\n")
+	}
+}
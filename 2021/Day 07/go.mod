@@ -0,0 +1,3 @@
+module day7
+
+go 1.21
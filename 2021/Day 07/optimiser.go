@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+)
+
+// CostFunc returns the fuel a crab burns moving the specified distance. For the
+// optimiser to find the best position it must be convex - each extra step costs at
+// least as much as the one before it.
+type CostFunc func(distance int) int
+
+// Cost is a named fuel cost. A polynomial cost, (linear*d + quadratic*d*d) / divisor,
+// can be totalled over every crab in O(1) using prefix sums. Any other cost is totalled
+// crab by crab.
+type Cost struct {
+	Name string
+	Fuel CostFunc
+
+	polynomial                 bool
+	linear, quadratic, divisor int
+}
+
+// PolynomialCost() creates a Cost where moving d steps burns
+// (linear*d + quadratic*d*d) / divisor fuel. The division must be exact for every d.
+func PolynomialCost(name string, linear int, quadratic int, divisor int) Cost {
+	return Cost{
+		Name:       name,
+		Fuel:       func(d int) int { return (linear*d + quadratic*d*d) / divisor },
+		polynomial: true,
+		linear:     linear,
+		quadratic:  quadratic,
+		divisor:    divisor,
+	}
+}
+
+// CustomCost() creates a Cost from any convex cost function.
+func CustomCost(name string, fuel CostFunc) Cost {
+	return Cost{Name: name, Fuel: fuel}
+}
+
+// ConstantBurn is the cost from part one - each step burns one unit of fuel.
+var ConstantBurn = PolynomialCost("constant burn", 1, 0, 1)
+
+// IncreasingBurn is the cost from part two - each step burns one more unit of fuel
+// than the last, so d steps burn 1 + 2 + ... + d = (d + d*d) / 2.
+var IncreasingBurn = PolynomialCost("increasing burn", 1, 1, 2)
+
+// Method is the way an Optimiser looks for the cheapest position.
+type Method int
+
+const (
+	// TernarySearch narrows the range of positions by thirds, which relies on the cost
+	// being convex and takes O(log(range)) evaluations.
+	TernarySearch Method = iota
+	// ExactScan evaluates every position in the range.
+	ExactScan
+)
+
+// Optimiser finds the position that the crabs can all move to for the least fuel.
+type Optimiser struct {
+	positions []int
+	min, max  int
+
+	// counts[i] and sums[i] are the number of crabs and the sum of their positions for
+	// the crabs at positions before min+i
+	counts, sums []int
+	total        int
+	totalSquares int
+}
+
+// NewOptimiser() creates an Optimiser for crabs at the specified positions. The
+// 'positions' slice isn't modified.
+func NewOptimiser(positions []int) (*Optimiser, error) {
+	if len(positions) == 0 {
+		return nil, fmt.Errorf("there are no crabs")
+	}
+
+	o := &Optimiser{positions: slices.Clone(positions)}
+	slices.Sort(o.positions)
+	o.min, o.max = o.positions[0], o.positions[len(o.positions)-1]
+
+	size := o.max - o.min + 2
+	o.counts, o.sums = make([]int, size), make([]int, size)
+	for _, p := range o.positions {
+		o.counts[p-o.min+1]++
+		o.sums[p-o.min+1] += p
+		o.total += p
+		o.totalSquares += p * p
+	}
+
+	for i := 1; i < size; i++ {
+		o.counts[i] += o.counts[i-1]
+		o.sums[i] += o.sums[i-1]
+	}
+
+	return o, nil
+}
+
+// Range() returns the lowest and highest positions of the crabs. The cheapest position
+// for a convex cost is always between them.
+func (o *Optimiser) Range() (int, int) {
+	return o.min, o.max
+}
+
+// Cost() returns the total fuel the crabs burn to move to 'position'.
+func (o *Optimiser) Cost(c Cost, position int) int {
+	if !c.polynomial {
+		fuel := 0
+		for _, p := range o.positions {
+			fuel += c.Fuel(calculateAbsValue(p - position))
+		}
+
+		return fuel
+	}
+
+	n := len(o.positions)
+
+	// the crabs before the position and those at or after it
+	i := min(max(position-o.min, 0), len(o.counts)-1)
+	before, beforeSum := o.counts[i], o.sums[i]
+	after, afterSum := n-before, o.total-beforeSum
+
+	distances := position*before - beforeSum + afterSum - position*after
+	squares := o.totalSquares - 2*position*o.total + n*position*position
+
+	return (c.linear*distances + c.quadratic*squares) / c.divisor
+}
+
+// Result is the cheapest position and the fuel needed to move every crab there.
+type Result struct {
+	Position int
+	Fuel     int
+}
+
+// Optimise() finds the cheapest position using the specified method. When several
+// positions cost the same, the lowest is returned.
+func (o *Optimiser) Optimise(c Cost, method Method) Result {
+	lo, hi := o.min, o.max
+
+	if method == TernarySearch {
+		for hi-lo > 2 {
+			m1, m2 := lo+(hi-lo)/3, hi-(hi-lo)/3
+
+			switch c1, c2 := o.Cost(c, m1), o.Cost(c, m2); {
+			case c1 < c2:
+				hi = m2 - 1
+			case c1 > c2:
+				lo = m1 + 1
+			default:
+				// a convex cost is lowest between two equal costs
+				lo, hi = m1, m2
+			}
+		}
+	}
+
+	best := Result{Position: lo, Fuel: o.Cost(c, lo)}
+	for p := lo + 1; p <= hi; p++ {
+		if fuel := o.Cost(c, p); fuel < best.Fuel {
+			best = Result{Position: p, Fuel: fuel}
+		}
+	}
+
+	if method == TernarySearch {
+		// the search can stop anywhere on a flat bottom, but a convex cost never rises
+		// on the way down to the cheapest position, so the lowest position with the
+		// same cost is the first one at or below it
+		best.Position = o.min + sort.Search(best.Position-o.min, func(i int) bool {
+			return o.Cost(c, o.min+i) <= best.Fuel
+		})
+	}
+
+	return best
+}
+
+// Curve() returns the cost of moving to each position from the lowest crab position to
+// the highest.
+func (o *Optimiser) Curve(c Cost) []int {
+	curve := make([]int, 0, o.max-o.min+1)
+	for p := o.min; p <= o.max; p++ {
+		curve = append(curve, o.Cost(c, p))
+	}
+
+	return curve
+}
+
+// WriteCurve() writes the cost curve as comma-separated values for plotting.
+func (o *Optimiser) WriteCurve(w io.Writer, c Cost) error {
+	if _, err := fmt.Fprintf(w, "position,fuel\n"); err != nil {
+		return err
+	}
+
+	for i, fuel := range o.Curve(c) {
+		if _, err := fmt.Fprintf(w, "%d,%d\n", o.min+i, fuel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,167 +2,118 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"strconv"
 	"strings"
+
+	"sciencerocketry.com/explain"
 )
 
 const numPatterns = 10
 const numOutput = 4
 
 type Entry struct {
-	patterns                                                              []string
-	output                                                                []string
-	top, middle, bottom, upper_left, upper_right, lower_left, lower_right string
+	patterns []string
+	output   []string
+	solution *Solution
 }
 
-func NewEntry(input string) *Entry {
-	if len(input) <= 0 {
-		// insufficient input
-		return nil
+// NewEntry() parses an entry such as 'acedgfb cdfbe ... | cdfeb fcadb cdfeb cdbaf' and
+// solves the wiring from its patterns.
+func NewEntry(input string) (*Entry, error) {
+	patterns, output, found := strings.Cut(input, "|")
+	if !found {
+		return nil, fmt.Errorf("the entry '%s' has no '|'", input)
 	}
 
 	entry := new(Entry)
+	entry.patterns = strings.Fields(patterns)
+	entry.output = strings.Fields(output)
 
-	parts := strings.Split(input, "|")
-	entry.patterns = strings.Split(strings.TrimSpace(parts[0]), " ")
-	entry.output = strings.Split(strings.TrimSpace(parts[1]), " ")
-	entry.processPatterns()
-
-	return entry
-}
-
-func (e *Entry) processPatterns() {
-	var one, four, seven, eight string
-	var len6, len5 []string
-
-	for _, pattern := range e.patterns {
-		lenPattern := len(pattern)
-		switch lenPattern {
-		case 2:
-			// the digit is a 1
-			one = pattern
-		case 3:
-			// the digit is a 7
-			seven = pattern
-		case 4:
-			// the digit is a 4
-			four = pattern
-		case 7:
-			// the digit is an 8
-			eight = pattern
-		case 6:
-			len6 = append(len6, pattern)
-		case 5:
-			len5 = append(len5, pattern)
-		}
+	if len(entry.output) != numOutput {
+		return nil, fmt.Errorf("the entry '%s' has %d output digits instead of %d", input, len(entry.output), numOutput)
 	}
 
-	for _, pattern := range len6 {
-		difference := subtractString(one, pattern)
-		if len(difference) == 1 {
-			// this is a six and the difference is the upper-right segment
-			e.upper_right = difference
-			e.lower_right = subtractString(one, e.upper_right)
-			continue
-		}
-
-		difference = subtractString(four, pattern)
-		if len(difference) == 1 {
-			// this is a zero and the difference is the middle segment
-			e.middle = difference
-			continue
-		}
-
-		// this is a 9 and the difference is the lower-left segment
-		difference = subtractString(eight, pattern)
-		e.lower_left = difference
+	solution, err := Solve(entry.patterns)
+	if err != nil {
+		return nil, err
 	}
 
-	e.top = subtractString(seven, one)
-	e.upper_left = subtractString(four, one+e.middle)
-	e.bottom = subtractString(eight, e.top+e.middle+e.upper_left+e.upper_right+e.lower_left+e.lower_right)
+	entry.solution = solution
 
-	return
+	return entry, nil
 }
 
-func subtractString(a, b string) (diff string) {
-	for _, a_char := range a {
-		if !strings.Contains(b, string(a_char)) {
-			diff += string(a_char)
+// OutputValue() decodes the four output digits into a number.
+func (e *Entry) OutputValue() (int, error) {
+	outputVal := 0
+	for _, pattern := range e.output {
+		digit, err := e.solution.Digit(pattern)
+		if err != nil {
+			return 0, err
 		}
+
+		outputVal = outputVal*10 + digit
 	}
-	return
-}
 
-func (e *Entry) evaluate(input string) int {
-	switch len(input) {
-	case 2:
-		// the digit is a 1
-		return 1
-	case 3:
-		// the digit is a 7
-		return 7
-	case 4:
-		// the digit is a 4
-		return 4
-	case 7:
-		// the digit is an 8
-		return 8
-	case 5:
-		if strings.Contains(input, e.lower_left) {
-			// the digit is a 2
-			return 2
-		}
+	return outputVal, nil
+}
 
-		if strings.Contains(input, e.upper_left) {
-			// the digit is a 5
-			return 5
-		}
+// Print() draws the display with each segment labelled by the wire that drives it.
+func (e *Entry) Print(w io.Writer) {
+	fmt.Fprintf(w, "patterns: %v - output: %v\n", e.patterns, e.output)
 
-		return 3
-	case 6:
-		if subtractString("abcdefg", input) == e.middle {
-			return 0
+	label := func(segment byte) string { return "?" }
+	if wiring, err := e.solution.Wiring(); err == nil {
+		label = func(segment byte) string {
+			return string(segmentNames[wiring.wireFor(strings.IndexByte(segmentNames, segment))])
 		}
-		if subtractString("abcdefg", input) == e.upper_right {
-			return 6
-		}
-
-		return 9
 	}
 
-	return -1
-}
+	top, upperLeft, upperRight, middle := label('a'), label('b'), label('c'), label('d')
+	lowerLeft, lowerRight, bottom := label('e'), label('f'), label('g')
+
+	fmt.Fprintf(w, " %s \n", strings.Repeat(top, 4))
+	fmt.Fprintf(w, "%s    %s\n", upperLeft, upperRight)
+	fmt.Fprintf(w, "%s    %s\n", upperLeft, upperRight)
+	fmt.Fprintf(w, " %s \n", strings.Repeat(middle, 4))
+	fmt.Fprintf(w, "%s    %s\n", lowerLeft, lowerRight)
+	fmt.Fprintf(w, "%s    %s\n", lowerLeft, lowerRight)
+	fmt.Fprintf(w, " %s \n", strings.Repeat(bottom, 4))
+	fmt.Fprintf(w, "\n")
 
-func (e *Entry) OutputValue() string {
-	var outputVal string
-	for _, digit := range e.output {
-		outputVal += strconv.Itoa(e.evaluate(digit))
+	if outputVal, err := e.OutputValue(); err == nil {
+		fmt.Fprintf(w, "output value: %d\n", outputVal)
+	} else {
+		fmt.Fprintf(w, "output value: %v\n", err)
 	}
-	return outputVal
-}
 
-func (e *Entry) Print(w io.Writer) {
-	fmt.Fprintf(w, "patterns: %v - output: %v\n", e.patterns, e.output)
-	fmt.Fprintf(w, " %s%s%s%s \n", e.top, e.top, e.top, e.top)
-	fmt.Fprintf(w, "%s    %s\n", e.upper_left, e.upper_right)
-	fmt.Fprintf(w, "%s    %s\n", e.upper_left, e.upper_right)
-	fmt.Fprintf(w, " %s%s%s%s \n", e.middle, e.middle, e.middle, e.middle)
-	fmt.Fprintf(w, "%s    %s\n", e.lower_left, e.lower_right)
-	fmt.Fprintf(w, "%s    %s\n", e.lower_left, e.lower_right)
-	fmt.Fprintf(w, " %s%s%s%s \n", e.bottom, e.bottom, e.bottom, e.bottom)
-	fmt.Fprintf(w, "\n")
-	fmt.Fprintf(w, "output value: %s\n", e.OutputValue())
 	fmt.Fprintf(w, "\n")
 }
 
+// main() prints the answers to both parts of the puzzle. It receives the name of the
+// data file containing the entries. '-explain' explains what each entry adds to the
+// answers, including how its wiring was deduced, as text or JSON, on stderr or in the
+// '-explain-to' file:
+//
+//	day8 [-explain text|json [-explain-to file]] day8.txt
 func main() {
-	var inputFile string
-	inputFile = os.Args[1]
+	explainFormat := flag.String("explain", "", "explain what each entry adds to the answers, as 'text' or 'json'")
+	explainTo := flag.String("explain-to", "", "write the explanations to this `file` instead of stderr")
+	flag.Parse()
+
+	explainer, err := explain.Open(*explainFormat, *explainTo)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer explainer.Close()
+
+	inputFile := flag.Arg(0)
 
 	fileContents, err := ReadFile(inputFile)
 	if err != nil {
@@ -181,14 +132,75 @@ func main() {
 
 	// import entry data
 	for i := 0; i < numLines; i++ {
-		entries = append(entries, NewEntry(fileContents[i]))
+		entry, err := NewEntry(fileContents[i])
+		if err != nil {
+			log.Fatal(fmt.Errorf("line %d: %w", i+1, err))
+		}
+
+		entries = append(entries, entry)
 	}
 
 	numUniqueSegments := countUniqueSegments(entries)
 	fmt.Printf("Part One - digits 1, 4, 7, or 8 appear %d times\n", numUniqueSegments)
 
-	sumOutputs := sumOutputValues(entries)
+	sumOutputs, err := sumOutputValues(entries)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	fmt.Printf("Part Two - sum of the output values: %d\n", sumOutputs)
+
+	if err := explainEntries(explainer, entries); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// explainEntries() explains what each entry adds to the answers - the output digits
+// with a unique number of segments for part 1, and the output value, along with how
+// the wiring was deduced, for part 2.
+func explainEntries(e *explain.Explainer, entries []*Entry) error {
+	if !e.Enabled() {
+		return nil
+	}
+
+	for i, entry := range entries {
+		var unique []string
+		for _, digit := range entry.output {
+			if checkDigitUnique(digit) {
+				unique = append(unique, digit)
+			}
+		}
+
+		ex := explain.Explanation{Part: 1, Item: "entry " + strconv.Itoa(i+1), Value: len(unique), Reason: fmt.Sprintf("the output digits %v are a 1, 4, 7 or 8", unique)}
+		if len(unique) == 0 {
+			ex.Reason = "none of the output digits is a 1, 4, 7 or 8"
+		}
+
+		if err := e.Explain(ex); err != nil {
+			return err
+		}
+	}
+
+	for i, entry := range entries {
+		value, err := entry.OutputValue()
+		if err != nil {
+			return fmt.Errorf("entry %d: %w", i+1, err)
+		}
+
+		ex := explain.Explanation{
+			Part:    2,
+			Item:    "entry " + strconv.Itoa(i+1),
+			Value:   value,
+			Reason:  fmt.Sprintf("the output %v reads %04d", entry.output, value),
+			Details: explain.Lines(entry.solution.Explain),
+		}
+
+		if err := e.Explain(ex); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func countUniqueSegments(entries []*Entry) int {
@@ -226,21 +238,19 @@ func checkDigitUnique(digit string) bool {
 	return false
 }
 
-func sumOutputValues(entries []*Entry) int {
+func sumOutputValues(entries []*Entry) (int, error) {
 	sumOutput := 0
 
-	numEntries := len(entries)
-	for i := 0; i < numEntries; i++ {
-		entry := entries[i]
-		output, err := strconv.Atoi(entry.OutputValue())
+	for i, entry := range entries {
+		output, err := entry.OutputValue()
 		if err != nil {
-			log.Fatal("Couldn't convert the output value to a numeric value")
+			return 0, fmt.Errorf("entry %d: %w", i+1, err)
 		}
 
 		sumOutput += output
 	}
 
-	return sumOutput
+	return sumOutput, nil
 }
 
 func ReadFile(filename string) ([]string, error) {
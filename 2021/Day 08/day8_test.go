@@ -0,0 +1,128 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"sciencerocketry.com/explain"
+)
+
+// TestSolve() decodes the output digits of the puzzle's example entry.
+func TestSolve(t *testing.T) {
+	entry, err := NewEntry("acedgfb cdfbe gcdfa fbcad dab cefabd cdfgeb eafb cagedb ab | cdfeb fcadb cdfeb cdbaf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !entry.solution.Unique() {
+		t.Errorf("Unique(): %d wirings fit\n", len(entry.solution.Wirings))
+	}
+
+	if got, err := entry.OutputValue(); err != nil || got != 5353 {
+		t.Errorf("OutputValue():\nwant %d\ngot  %d (%v)\n", 5353, got, err)
+	}
+}
+
+var partialTests = []struct {
+	patterns []string
+	unique   bool
+	digits   map[string]int
+}{
+	// without the 2, 3 and 5 there's still only one wiring that fits
+	{
+		patterns: []string{"acedgfb", "dab", "cefabd", "cdfgeb", "eafb", "cagedb", "ab"},
+		unique:   true,
+		digits:   map[string]int{"cdfbe": 5, "gcdfa": 2, "fbcad": 3},
+	},
+	// a 1 and a 7 don't fix the wiring, but they are enough to read some digits
+	{
+		patterns: []string{"ab", "dab"},
+		unique:   false,
+		digits:   map[string]int{"ba": 1, "bad": 7, "gfedcba": 8},
+	},
+	// with no patterns at all, every permutation fits
+	{
+		patterns: nil,
+		unique:   false,
+		digits:   map[string]int{"abcdefg": 8},
+	},
+}
+
+// TestPartialPatterns() solves entries that only have some of the ten patterns.
+func TestPartialPatterns(t *testing.T) {
+	for _, test := range partialTests {
+		s, err := Solve(test.patterns)
+		if err != nil {
+			t.Errorf("Solve(%v): %v\n", test.patterns, err)
+			continue
+		}
+
+		if s.Unique() != test.unique {
+			t.Errorf("Solve(%v).Unique():\nwant %t\ngot  %t (%d wirings)\n", test.patterns, test.unique, s.Unique(), len(s.Wirings))
+		}
+
+		for pattern, want := range test.digits {
+			if got, err := s.Digit(pattern); err != nil || got != want {
+				t.Errorf("Solve(%v).Digit(%s):\nwant %d\ngot  %d (%v)\n", test.patterns, pattern, want, got, err)
+			}
+		}
+	}
+
+	s, err := Solve(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.Wirings) != 5040 {
+		t.Errorf("Solve(nil):\nwant %d wirings\ngot  %d\n", 5040, len(s.Wirings))
+	}
+
+	if _, err := s.Digit("ab"); err == nil {
+		t.Errorf("Solve(nil).Digit(ab): expected an error as any two segments aren't always a 1\n")
+	}
+}
+
+// TestSolveErrors() checks patterns that no wiring can fit.
+func TestSolveErrors(t *testing.T) {
+	for _, patterns := range [][]string{
+		{"ab", "cd"},       // two different 1s
+		{"abcdefgh"},       // 'h' isn't a segment
+		{"aa"},             // repeated segment
+		{"ab", "abc", "a"}, // no digit has one segment
+	} {
+		if _, err := Solve(patterns); err == nil {
+			t.Errorf("Solve(%v): expected an error\n", patterns)
+		}
+	}
+}
+
+// TestExplainEntries() checks what the example entry is said to add to each part.
+func TestExplainEntries(t *testing.T) {
+	entry, err := NewEntry("acedgfb cdfbe gcdfa fbcad dab cefabd cdfgeb eafb cagedb ab | cdfeb fcadb cdfeb cdbaf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output strings.Builder
+	e, err := explain.New(&output, "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := explainEntries(e, []*Entry{entry}); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(output.String(), "\n")
+	if lines[0] != "part 1 - entry 1: 0 (none of the output digits is a 1, 4, 7 or 8)" {
+		t.Errorf("explainEntries() part 1:\nwant no unique digits\ngot  %s\n", lines[0])
+	}
+
+	if lines[1] != "part 2 - entry 1: 5353 (the output [cdfeb fcadb cdfeb cdbaf] reads 5353)" {
+		t.Errorf("explainEntries() part 2:\nwant 5353\ngot  %s\n", lines[1])
+	}
+
+	if !strings.Contains(output.String(), "exactly one wiring fits") {
+		t.Errorf("explainEntries() part 2:\nwant how the wiring was deduced\ngot  %s\n", output.String())
+	}
+}
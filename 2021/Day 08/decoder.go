@@ -0,0 +1,406 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+	"strings"
+)
+
+// the seven segments of a display, named by the wires that drive them when the
+// display is wired up correctly
+const segmentNames = "abcdefg"
+const numSegments = len(segmentNames)
+
+// allSegments has a bit set for each of the seven segments.
+const allSegments = 1<<numSegments - 1
+
+// segmentDescriptions are the positions of the segments 'a' to 'g' on the display.
+var segmentDescriptions = [numSegments]string{
+	"top", "upper left", "upper right", "middle", "lower left", "lower right", "bottom",
+}
+
+// digitSegments holds the segments lit for each of the digits 0 to 9, with a bit set
+// for each segment ('a' is bit 0).
+var digitSegments = [10]uint8{
+	segmentMask("abcefg"),  // 0
+	segmentMask("cf"),      // 1
+	segmentMask("acdeg"),   // 2
+	segmentMask("acdfg"),   // 3
+	segmentMask("bcdf"),    // 4
+	segmentMask("abdfg"),   // 5
+	segmentMask("abdefg"),  // 6
+	segmentMask("acf"),     // 7
+	segmentMask("abcdefg"), // 8
+	segmentMask("abcdfg"),  // 9
+}
+
+// allDigits has a bit set for each of the digits 0 to 9.
+const allDigits = 1<<len(digitSegments) - 1
+
+// segmentMask() converts a pattern such as 'acf' into a bit set. Characters that
+// aren't segments are ignored.
+func segmentMask(pattern string) uint8 {
+	var mask uint8
+	for _, c := range pattern {
+		if i := strings.IndexRune(segmentNames, c); i >= 0 {
+			mask |= 1 << i
+		}
+	}
+
+	return mask
+}
+
+// maskString() converts a bit set of segments back into a pattern such as 'acf'.
+func maskString(mask uint8) string {
+	var sb strings.Builder
+	for i := 0; i < numSegments; i++ {
+		if mask&(1<<i) != 0 {
+			sb.WriteByte(segmentNames[i])
+		}
+	}
+
+	return sb.String()
+}
+
+// digitString() lists the digits in a bit set of digits, e.g. '2, 3 or 5'.
+func digitString(digits uint16) string {
+	var names []string
+	for d := range digitSegments {
+		if digits&(1<<d) != 0 {
+			names = append(names, fmt.Sprint(d))
+		}
+	}
+
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+// Wiring is a permutation mapping each of the signal wires 'a' to 'g' (by index) to
+// the segment it actually drives.
+type Wiring [numSegments]int
+
+// Translate() converts a pattern of signal wires into the segments they light.
+func (w Wiring) Translate(pattern uint8) uint8 {
+	var segments uint8
+	for wire := 0; wire < numSegments; wire++ {
+		if pattern&(1<<wire) != 0 {
+			segments |= 1 << w[wire]
+		}
+	}
+
+	return segments
+}
+
+// wireFor() returns the wire that drives the specified segment.
+func (w Wiring) wireFor(segment int) int {
+	for wire, s := range w {
+		if s == segment {
+			return wire
+		}
+	}
+
+	return -1
+}
+
+// constraints is the state of the search for a Wiring. Each wire has a set of the
+// segments it might drive and each distinct pattern has a set of the digits it might be.
+type constraints struct {
+	patterns []uint8
+	wires    [numSegments]uint8
+	digits   []uint16
+}
+
+// feasible() reports whether the pattern at index 'p' can still be the digit 'd'. Each
+// wire in the pattern must be able to drive a segment of the digit, each wire outside
+// the pattern must be able to drive a segment that isn't, and the wires in the pattern
+// must be able to cover all of the digit's segments between them.
+func (c *constraints) feasible(p int, d int) bool {
+	pattern, segments := c.patterns[p], digitSegments[d]
+	if bits.OnesCount8(pattern) != bits.OnesCount8(segments) {
+		return false
+	}
+
+	var covered uint8
+	for wire := 0; wire < numSegments; wire++ {
+		if pattern&(1<<wire) != 0 {
+			if c.wires[wire]&segments == 0 {
+				return false
+			}
+
+			covered |= c.wires[wire]
+		} else if c.wires[wire]&^segments&allSegments == 0 {
+			return false
+		}
+	}
+
+	return covered&segments == segments
+}
+
+// propagate() narrows the candidate digits and segments until nothing changes. It
+// returns false if the constraints contradict each other. Each deduction is passed to
+// 'note', which may be nil.
+func (c *constraints) propagate(note func(string)) bool {
+	for changed := true; changed; {
+		changed = false
+
+		for p, pattern := range c.patterns {
+			// rule out the digits this pattern can no longer be
+			digits := c.digits[p]
+			for d := range digitSegments {
+				if digits&(1<<d) != 0 && !c.feasible(p, d) {
+					digits &^= 1 << d
+				}
+			}
+
+			if digits == 0 {
+				return false
+			}
+
+			if digits != c.digits[p] {
+				c.digits[p] = digits
+				changed = true
+
+				if note != nil && bits.OnesCount16(digits) == 1 {
+					note(fmt.Sprintf("pattern '%s' must be the digit %s", maskString(pattern), digitString(digits)))
+				}
+			}
+
+			// different patterns must be different digits
+			if bits.OnesCount16(digits) == 1 {
+				for other := range c.patterns {
+					if other != p && c.digits[other]&digits != 0 {
+						c.digits[other] &^= digits
+						changed = true
+					}
+				}
+			}
+
+			// a wire in the pattern drives a segment of one of the possible digits and a
+			// wire outside it drives a segment that isn't
+			var inside, outside uint8
+			for d := range digitSegments {
+				if digits&(1<<d) != 0 {
+					inside |= digitSegments[d]
+					outside |= ^digitSegments[d] & allSegments
+				}
+			}
+
+			for wire := 0; wire < numSegments; wire++ {
+				allowed := outside
+				if pattern&(1<<wire) != 0 {
+					allowed = inside
+				}
+
+				if c.wires[wire]&allowed != c.wires[wire] {
+					changed = c.narrow(wire, c.wires[wire]&allowed, fmt.Sprintf("pattern '%s'", maskString(pattern)), note) || changed
+				}
+			}
+		}
+
+		for wire := 0; wire < numSegments; wire++ {
+			if c.wires[wire] == 0 {
+				return false
+			}
+
+			// a wire known to drive a segment is the only wire driving it
+			if bits.OnesCount8(c.wires[wire]) == 1 {
+				for other := 0; other < numSegments; other++ {
+					if other != wire && c.wires[other]&c.wires[wire] != 0 {
+						changed = c.narrow(other, c.wires[other]&^c.wires[wire], "elimination", note) || changed
+					}
+				}
+			}
+		}
+
+		// a segment that only one wire can drive must be driven by that wire
+		for segment := 0; segment < numSegments; segment++ {
+			only := -1
+			for wire := 0; wire < numSegments; wire++ {
+				if c.wires[wire]&(1<<segment) != 0 {
+					if only >= 0 {
+						only = -2
+						break
+					}
+
+					only = wire
+				}
+			}
+
+			if only == -1 {
+				return false
+			}
+
+			if only >= 0 && c.wires[only] != 1<<segment {
+				changed = c.narrow(only, 1<<segment, "the only wire left for it", note) || changed
+			}
+		}
+	}
+
+	return true
+}
+
+// narrow() restricts the segments a wire might drive, noting when the wire has been
+// pinned down to a single segment.
+func (c *constraints) narrow(wire int, segments uint8, reason string, note func(string)) bool {
+	if c.wires[wire] == segments {
+		return false
+	}
+
+	c.wires[wire] = segments
+	if note != nil && bits.OnesCount8(segments) == 1 {
+		segment := bits.TrailingZeros8(segments)
+		note(fmt.Sprintf("wire '%c' drives the %s segment (%s)", segmentNames[wire], segmentDescriptions[segment], reason))
+	}
+
+	return true
+}
+
+// search() tries each remaining choice for the least constrained wire, adding every
+// complete Wiring that fits to 'solutions'.
+func (c constraints) search(solutions *[]Wiring) {
+	if !c.propagate(nil) {
+		return
+	}
+
+	choice := -1
+	for wire := 0; wire < numSegments; wire++ {
+		n := bits.OnesCount8(c.wires[wire])
+		if n > 1 && (choice < 0 || n < bits.OnesCount8(c.wires[choice])) {
+			choice = wire
+		}
+	}
+
+	if choice < 0 {
+		var w Wiring
+		for wire := 0; wire < numSegments; wire++ {
+			w[wire] = bits.TrailingZeros8(c.wires[wire])
+		}
+
+		*solutions = append(*solutions, w)
+		return
+	}
+
+	for segment := 0; segment < numSegments; segment++ {
+		if c.wires[choice]&(1<<segment) == 0 {
+			continue
+		}
+
+		next := c
+		next.digits = append([]uint16(nil), c.digits...)
+		next.wires[choice] = 1 << segment
+		next.search(solutions)
+	}
+}
+
+// Solution holds every Wiring that fits a set of patterns, along with the deductions
+// that were made to find them.
+type Solution struct {
+	Wirings []Wiring
+	steps   []string
+}
+
+// Solve() finds the wirings that turn each of the patterns into a valid digit. Any
+// number of the ten patterns can be given. With all ten there is exactly one wiring,
+// but with fewer there might be several that fit.
+func Solve(patterns []string) (*Solution, error) {
+	c := constraints{}
+	for wire := range c.wires {
+		c.wires[wire] = allSegments
+	}
+
+	seen := map[uint8]bool{}
+	for _, pattern := range patterns {
+		mask := segmentMask(pattern)
+		if bits.OnesCount8(mask) != len(pattern) {
+			return nil, fmt.Errorf("the pattern '%s' isn't made of distinct segments a-g", pattern)
+		}
+
+		if !seen[mask] {
+			seen[mask] = true
+			c.patterns = append(c.patterns, mask)
+			c.digits = append(c.digits, allDigits)
+		}
+	}
+
+	if len(c.patterns) > len(digitSegments) {
+		return nil, fmt.Errorf("there are %d different patterns, but only %d digits", len(c.patterns), len(digitSegments))
+	}
+
+	s := &Solution{}
+	if !c.propagate(func(step string) { s.steps = append(s.steps, step) }) {
+		return nil, fmt.Errorf("no wiring fits the patterns %v", patterns)
+	}
+
+	c.search(&s.Wirings)
+	if len(s.Wirings) == 0 {
+		return nil, fmt.Errorf("no wiring fits the patterns %v", patterns)
+	}
+
+	return s, nil
+}
+
+// Unique() reports whether exactly one Wiring fits the patterns.
+func (s *Solution) Unique() bool {
+	return len(s.Wirings) == 1
+}
+
+// Wiring() returns the Wiring that fits the patterns, or an error if more than one does.
+func (s *Solution) Wiring() (Wiring, error) {
+	if !s.Unique() {
+		return Wiring{}, fmt.Errorf("%d wirings fit the patterns", len(s.Wirings))
+	}
+
+	return s.Wirings[0], nil
+}
+
+// Digit() decodes a pattern into the digit it shows. It works even when several
+// wirings fit, as long as they all agree on the digit.
+func (s *Solution) Digit(pattern string) (int, error) {
+	mask := segmentMask(pattern)
+
+	digit := -1
+	for _, w := range s.Wirings {
+		segments := w.Translate(mask)
+
+		d := -1
+		for i, lit := range digitSegments {
+			if lit == segments {
+				d = i
+				break
+			}
+		}
+
+		if d < 0 {
+			return -1, fmt.Errorf("the pattern '%s' isn't a digit", pattern)
+		}
+
+		if digit >= 0 && d != digit {
+			return -1, fmt.Errorf("the pattern '%s' could be a %d or a %d", pattern, digit, d)
+		}
+
+		digit = d
+	}
+
+	return digit, nil
+}
+
+// Explain() writes the deductions that led to the wiring and the mapping that was chosen.
+func (s *Solution) Explain(w io.Writer) {
+	for _, step := range s.steps {
+		fmt.Fprintf(w, "  %s\n", step)
+	}
+
+	if !s.Unique() {
+		fmt.Fprintf(w, "  %d wirings fit the patterns\n", len(s.Wirings))
+		return
+	}
+
+	fmt.Fprintf(w, "  exactly one wiring fits:\n")
+	for wire, segment := range s.Wirings[0] {
+		fmt.Fprintf(w, "    %c -> %c (%s)\n", segmentNames[wire], segmentNames[segment], segmentDescriptions[segment])
+	}
+}
@@ -0,0 +1,11 @@
+module day8
+
+go 1.21
+
+require (
+    sciencerocketry.com/explain v0.0.0
+)
+
+replace (
+    sciencerocketry.com/explain => ../explain
+)
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"sort"
+)
+
+// Point is a location on the height map.
+type Point struct {
+	X, Y int
+}
+
+// the highest point, which is never part of a basin
+const maxHeight = 9
+
+// HeightMap holds the height of each location on the cave floor.
+type HeightMap struct {
+	heights       [][]int
+	width, height int
+}
+
+// NewHeightMap() parses rows of digits into a HeightMap. Every row must be the same
+// length.
+func NewHeightMap(lines []string) (*HeightMap, error) {
+	if len(lines) == 0 || len(lines[0]) == 0 {
+		return nil, fmt.Errorf("the height map is empty")
+	}
+
+	h := &HeightMap{width: len(lines[0]), height: len(lines)}
+	h.heights = make([][]int, h.height)
+
+	for y, line := range lines {
+		if len(line) != h.width {
+			return nil, fmt.Errorf("line %d: the row is %d long instead of %d", y+1, len(line), h.width)
+		}
+
+		h.heights[y] = make([]int, h.width)
+		for x, c := range line {
+			if c < '0' || c > '9' {
+				return nil, fmt.Errorf("line %d: '%c' at column %d isn't a height", y+1, c, x+1)
+			}
+
+			h.heights[y][x] = int(c - '0')
+		}
+	}
+
+	return h, nil
+}
+
+// At() returns the height at 'p'.
+func (h *HeightMap) At(p Point) int {
+	return h.heights[p.Y][p.X]
+}
+
+// neighbours() returns the points above, below, left and right of 'p' that are on the map.
+func (h *HeightMap) neighbours(p Point) []Point {
+	adjacent := make([]Point, 0, 4)
+	for _, d := range []Point{{0, -1}, {-1, 0}, {1, 0}, {0, 1}} {
+		n := Point{X: p.X + d.X, Y: p.Y + d.Y}
+		if n.X >= 0 && n.X < h.width && n.Y >= 0 && n.Y < h.height {
+			adjacent = append(adjacent, n)
+		}
+	}
+
+	return adjacent
+}
+
+// LowPoints() returns the points that are lower than all of their neighbours.
+func (h *HeightMap) LowPoints() []Point {
+	var lowPoints []Point
+
+	for y := 0; y < h.height; y++ {
+		for x := 0; x < h.width; x++ {
+			p := Point{X: x, Y: y}
+
+			lowest := true
+			for _, n := range h.neighbours(p) {
+				if h.At(n) <= h.At(p) {
+					lowest = false
+					break
+				}
+			}
+
+			if lowest {
+				lowPoints = append(lowPoints, p)
+			}
+		}
+	}
+
+	return lowPoints
+}
+
+// SumRiskLevels() returns the sum of the risk levels (height + 1) of the low points.
+func (h *HeightMap) SumRiskLevels() int {
+	sum := 0
+	for _, p := range h.LowPoints() {
+		sum += h.At(p) + riskLevel
+	}
+
+	return sum
+}
+
+// Basin is an area of the map that flows down to a single low point, surrounded by
+// locations of height 9 or the edge of the map.
+type Basin struct {
+	ID       int
+	LowPoint Point
+	Size     int
+	Cells    []Point
+}
+
+// BasinMap labels each location with the ID of the basin it belongs to. IDs start at 1
+// and locations of height 9 have the label 0.
+type BasinMap struct {
+	Basins []Basin
+	Labels [][]int
+}
+
+// Basins() finds every basin using an iterative flood fill from each unlabelled point,
+// so a large basin can't overflow the call stack. Basins are numbered in the order
+// they are found, scanning from the top left.
+func (h *HeightMap) Basins() *BasinMap {
+	m := &BasinMap{Labels: make([][]int, h.height)}
+	for y := range m.Labels {
+		m.Labels[y] = make([]int, h.width)
+	}
+
+	var pending []Point
+	for y := 0; y < h.height; y++ {
+		for x := 0; x < h.width; x++ {
+			start := Point{X: x, Y: y}
+			if m.Labels[y][x] != 0 || h.At(start) == maxHeight {
+				continue
+			}
+
+			b := Basin{ID: len(m.Basins) + 1, LowPoint: start}
+			m.Labels[y][x] = b.ID
+			pending = append(pending[:0], start)
+
+			for len(pending) > 0 {
+				p := pending[len(pending)-1]
+				pending = pending[:len(pending)-1]
+
+				b.Cells = append(b.Cells, p)
+				if h.At(p) < h.At(b.LowPoint) {
+					b.LowPoint = p
+				}
+
+				for _, n := range h.neighbours(p) {
+					if m.Labels[n.Y][n.X] == 0 && h.At(n) != maxHeight {
+						m.Labels[n.Y][n.X] = b.ID
+						pending = append(pending, n)
+					}
+				}
+			}
+
+			b.Size = len(b.Cells)
+			m.Basins = append(m.Basins, b)
+		}
+	}
+
+	return m
+}
+
+// Largest() returns the 'n' largest basins, largest first.
+func (m *BasinMap) Largest(n int) []Basin {
+	sorted := append([]Basin(nil), m.Basins...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Size > sorted[j].Size })
+
+	return sorted[:min(n, len(sorted))]
+}
+
+// wallColour is the colour of locations that aren't in a basin.
+var wallColour = color.RGBA{R: 40, G: 40, B: 40, A: 255}
+
+// basinColour() returns a distinct colour for each basin ID by stepping the hue around
+// the colour wheel by the golden angle.
+func basinColour(id int) color.RGBA {
+	if id == 0 {
+		return wallColour
+	}
+
+	hue := math.Mod(float64(id)*137.508, 360) / 60
+	x := 1 - math.Abs(math.Mod(hue, 2)-1)
+
+	var r, g, b float64
+	switch int(hue) {
+	case 0:
+		r, g = 1, x
+	case 1:
+		r, g = x, 1
+	case 2:
+		g, b = 1, x
+	case 3:
+		g, b = x, 1
+	case 4:
+		r, b = x, 1
+	default:
+		r, b = 1, x
+	}
+
+	// keep the colours bright enough to read the heights on
+	scale := func(v float64) uint8 { return uint8(80 + v*175) }
+
+	return color.RGBA{R: scale(r), G: scale(g), B: scale(b), A: 255}
+}
+
+// WriteANSI() writes the height map with each basin's heights on its own background
+// colour, using 24-bit ANSI escape codes.
+func (m *BasinMap) WriteANSI(w io.Writer, h *HeightMap) error {
+	for y, row := range m.Labels {
+		for x, id := range row {
+			c := basinColour(id)
+			if _, err := fmt.Fprintf(w, "\x1b[48;2;%d;%d;%dm\x1b[30m%d", c.R, c.G, c.B, h.heights[y][x]); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "\x1b[0m\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WritePNG() writes the basin map as a PNG image with each location drawn as a square
+// of 'scale' pixels.
+func (m *BasinMap) WritePNG(w io.Writer, scale int) error {
+	if scale <= 0 {
+		return fmt.Errorf("the scale must be at least 1 (%d)", scale)
+	}
+
+	height := len(m.Labels)
+	width := 0
+	if height > 0 {
+		width = len(m.Labels[0])
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width*scale, height*scale))
+	for y, row := range m.Labels {
+		for x, id := range row {
+			c := basinColour(id)
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetRGBA(x*scale+dx, y*scale+dy, c)
+				}
+			}
+		}
+	}
+
+	return png.Encode(w, img)
+}
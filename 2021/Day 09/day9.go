@@ -2,11 +2,10 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"sort"
-	"strconv"
 )
 
 func ReadFile(filename string) ([]string, error) {
@@ -24,9 +23,17 @@ func ReadFile(filename string) ([]string, error) {
 	return lines, nil
 }
 
+// main() prints the answers to both parts of the puzzle. It receives the name of the
+// data file containing the height map, and can also draw the basins:
+//
+//	day9 [-ansi] [-png basins.png] [-scale 4] day9.txt
 func main() {
-	var inputFile string
-	inputFile = os.Args[1]
+	ansi := flag.Bool("ansi", false, "print the basin map in colour to the terminal")
+	pngFile := flag.String("png", "", "write the basin map to this PNG file")
+	scale := flag.Int("scale", 4, "the size in pixels of each location in the PNG")
+	flag.Parse()
+
+	inputFile := flag.Arg(0)
 
 	fileContents, err := ReadFile(inputFile)
 	if err != nil {
@@ -39,141 +46,41 @@ func main() {
 		log.Fatal(fmt.Errorf("invalid input in %s\n", inputFile))
 	}
 
-	sumRiskLevels, productBasinSizes := getSumRiskLevelsAndProductLargestBasins(fileContents)
-	fmt.Printf("Part One - Sum of Risk Levels: %d\n", sumRiskLevels)
-	fmt.Printf("Part Two - Product of Basin Sizes: %d\n", productBasinSizes)
-}
-
-const riskLevel = 1
-
-func getSumRiskLevelsAndProductLargestBasins(data []string) (sumLowPoints int, productLargestBasins int) {
-	productLargestBasins = 1
-	largestBasins := []int{1, 1, 1}
-
-	for y, line := range data {
-		for x, point := range line {
-			if isLowest(point, x, y, data) {
-				sumLowPoints += Runetoi(point) + riskLevel
-				currentBasin := make(map[string]bool)
-				sizeBasin := sizeBasin(x, y, x, y, data, currentBasin)
-
-				sort.Ints(largestBasins)
-				if sizeBasin > largestBasins[0] {
-					largestBasins[0] = sizeBasin
-				}
-			}
-		}
-	}
-
-	for _, largeBasin := range largestBasins {
-		productLargestBasins *= largeBasin
+	heightMap, err := NewHeightMap(fileContents)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	return sumLowPoints, productLargestBasins
-}
-
-func isLowest(val rune, x int, y int, data []string) bool {
-	var xs, ys []int
+	basins := heightMap.Basins()
 
-	xs = append(xs, x)
-	ys = append(ys, y)
-
-	switch x {
-	case 0:
-		xs = append(xs, x+1)
-	case len(data[0]) - 1:
-		xs = append(xs, x-1)
-	default:
-		xs = append(xs, x-1)
-		xs = append(xs, x+1)
+	productBasinSizes := 1
+	for _, b := range basins.Largest(3) {
+		productBasinSizes *= b.Size
 	}
 
-	switch y {
-	case 0:
-		ys = append(ys, y+1)
-	case len(data) - 1:
-		ys = append(ys, y-1)
-	default:
-		ys = append(ys, y-1)
-		ys = append(ys, y+1)
-	}
+	fmt.Printf("Part One - Sum of Risk Levels: %d\n", heightMap.SumRiskLevels())
+	fmt.Printf("Part Two - Product of Basin Sizes: %d\n", productBasinSizes)
 
-	currentValue := Runetoi(val)
-	for _, xVal := range xs {
-		for _, yVal := range ys {
-			if currentValue >= Runetoi(rune(data[yVal][xVal])) {
-				if !(x == xVal && y == yVal) && !(x != xVal && y != yVal) {
-					return false
-				}
-			}
+	if *ansi {
+		if err := basins.WriteANSI(os.Stdout, heightMap); err != nil {
+			log.Fatal(err)
 		}
 	}
 
-	return true
-}
-
-func sizeBasin(x int, y int, currentX int, currentY int, data []string, currentBasin map[string]bool) int {
-	var xs, ys []int
-
-	xs = append(xs, x)
-	ys = append(ys, y)
-
-	switch x {
-	case 0:
-		xs = append(xs, x+1)
-	case len(data[0]) - 1:
-		xs = append(xs, x-1)
-	default:
-		xs = append(xs, x-1)
-		xs = append(xs, x+1)
-	}
-
-	switch y {
-	case 0:
-		ys = append(ys, y+1)
-	case len(data) - 1:
-		ys = append(ys, y-1)
-	default:
-		ys = append(ys, y-1)
-		ys = append(ys, y+1)
-	}
-
-	size := 1
-
-	coordinates := getCoordinateString(x, y)
-
-	currentValue := Runetoi(rune(data[y][x]))
-	if currentValue == 9 || currentBasin[coordinates] {
-		return 0
-	}
+	if len(*pngFile) > 0 {
+		file, err := os.Create(*pngFile)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	currentBasin[coordinates] = true
+		if err := basins.WritePNG(file, *scale); err != nil {
+			log.Fatal(err)
+		}
 
-	for _, xVal := range xs {
-		for _, yVal := range ys {
-			if !(x != xVal && y != yVal) {
-				// not a diagonal
-				currentBasin[coordinates] = true
-				size += sizeBasin(xVal, yVal, x, y, data, currentBasin)
-			}
+		if err := file.Close(); err != nil {
+			log.Fatal(err)
 		}
 	}
-
-	return size
 }
 
-func getCoordinateString(x int, y int) string {
-	xString := strconv.Itoa(x)
-	yString := strconv.Itoa(y)
-
-	return xString + "," + yString
-}
-
-func Runetoi(val rune) int {
-	iVal, err := strconv.Atoi(string(val))
-	if err != nil {
-		log.Fatal(fmt.Errorf("Error converting the rune value %s\n", string(val)))
-	}
-
-	return iVal
-}
+const riskLevel = 1
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+var sample = []string{
+	"2199943210",
+	"3987894921",
+	"9856789892",
+	"8767896789",
+	"9899965678",
+}
+
+// TestBasins() checks the low points and basins of the puzzle's example.
+func TestBasins(t *testing.T) {
+	h, err := NewHeightMap(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := h.SumRiskLevels(); got != 15 {
+		t.Errorf("SumRiskLevels():\nwant %d\ngot  %d\n", 15, got)
+	}
+
+	m := h.Basins()
+
+	want := []Basin{
+		{ID: 1, LowPoint: Point{1, 0}, Size: 3},
+		{ID: 2, LowPoint: Point{9, 0}, Size: 9},
+		{ID: 3, LowPoint: Point{2, 2}, Size: 14},
+		{ID: 4, LowPoint: Point{6, 4}, Size: 9},
+	}
+
+	if len(m.Basins) != len(want) {
+		t.Fatalf("Basins():\nwant %d basins\ngot  %d\n", len(want), len(m.Basins))
+	}
+
+	for i, b := range m.Basins {
+		if b.ID != want[i].ID || b.LowPoint != want[i].LowPoint || b.Size != want[i].Size || len(b.Cells) != b.Size {
+			t.Errorf("Basins()[%d]:\nwant %+v\ngot  %+v\n", i, want[i], b)
+		}
+
+		for _, c := range b.Cells {
+			if m.Labels[c.Y][c.X] != b.ID {
+				t.Errorf("Basins(): %v is in basin %d but labelled %d\n", c, b.ID, m.Labels[c.Y][c.X])
+			}
+		}
+	}
+
+	largest := m.Largest(3)
+	if product := largest[0].Size * largest[1].Size * largest[2].Size; product != 1134 {
+		t.Errorf("Largest(3):\nwant a product of %d\ngot  %d\n", 1134, product)
+	}
+}
+
+// TestExport() checks the size of the exported basin maps.
+func TestExport(t *testing.T) {
+	h, err := NewHeightMap(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := h.Basins()
+
+	var ansi bytes.Buffer
+	if err := m.WriteANSI(&ansi, h); err != nil {
+		t.Fatal(err)
+	}
+
+	if lines := strings.Count(ansi.String(), "\n"); lines != len(sample) {
+		t.Errorf("WriteANSI():\nwant %d lines\ngot  %d\n", len(sample), lines)
+	}
+
+	var buf bytes.Buffer
+	if err := m.WritePNG(&buf, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil || img.Bounds().Dx() != 30 || img.Bounds().Dy() != 15 {
+		t.Errorf("WritePNG():\nwant a 30x15 image\ngot  %v (%v)\n", img.Bounds(), err)
+	}
+
+	if _, err := NewHeightMap([]string{"123", "12"}); err == nil {
+		t.Errorf("NewHeightMap(): expected an error for a short row\n")
+	}
+}
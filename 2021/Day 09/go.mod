@@ -0,0 +1,3 @@
+module day9
+
+go 1.21
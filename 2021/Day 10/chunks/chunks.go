@@ -0,0 +1,173 @@
+// Package chunks validates lines made of nested chunks, where each chunk opens and
+// closes with a matching pair of delimiters such as '(' and ')'. The delimiter pairs
+// and the way lines are scored are configurable, so it can be used as a structural
+// linter for anything built from nested pairs.
+package chunks
+
+import (
+	"fmt"
+	"strings"
+
+	"sciencerocketry.com/stack"
+)
+
+// Pair is a pair of delimiters that open and close a chunk.
+type Pair struct {
+	Open  rune
+	Close rune
+}
+
+// BracketPairs are the four pairs of brackets used by the navigation subsystem.
+var BracketPairs = []Pair{
+	{Open: '(', Close: ')'},
+	{Open: '[', Close: ']'},
+	{Open: '{', Close: '}'},
+	{Open: '<', Close: '>'},
+}
+
+// Status describes the outcome of validating a line.
+type Status int
+
+const (
+	// Valid lines close every chunk they open with the matching delimiter.
+	Valid Status = iota
+	// Corrupted lines close a chunk with the wrong delimiter.
+	Corrupted
+	// Incomplete lines aren't corrupted but leave chunks open at the end of the line.
+	Incomplete
+)
+
+// String() returns the name of the status.
+func (s Status) String() string {
+	switch s {
+	case Valid:
+		return "valid"
+	case Corrupted:
+		return "corrupted"
+	case Incomplete:
+		return "incomplete"
+	}
+
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// Result is the outcome of validating a single line.
+type Result struct {
+	Status Status
+	// Found is the closing delimiter that corrupted the line.
+	Found rune
+	// Expected is the closing delimiter the line needed at that point, or 0 if there
+	// was no open chunk to close.
+	Expected rune
+	// Column is the 1-based column (in runes) of the corrupting delimiter.
+	Column int
+	// Completion is the sequence of closing delimiters that completes an incomplete
+	// line.
+	Completion string
+}
+
+// String() describes the result in the style of a linter message.
+func (r Result) String() string {
+	switch r.Status {
+	case Corrupted:
+		if r.Expected == 0 {
+			return fmt.Sprintf("column %d: unexpected '%c' with no open chunk", r.Column, r.Found)
+		}
+
+		return fmt.Sprintf("column %d: expected '%c', but found '%c' instead", r.Column, r.Expected, r.Found)
+	case Incomplete:
+		return fmt.Sprintf("incomplete: complete by adding '%s'", r.Completion)
+	}
+
+	return r.Status.String()
+}
+
+// Validator checks lines against a set of delimiter pairs.
+type Validator struct {
+	// closerFor maps each opening delimiter to its closing delimiter
+	closerFor map[rune]rune
+	// isCloser holds every closing delimiter
+	isCloser map[rune]bool
+}
+
+// NewValidator() creates a Validator for the specified delimiter pairs. A delimiter can
+// only be used once across all of the pairs, and a pair can't open and close with the
+// same delimiter because there would be no way to tell a chunk opening from one closing.
+func NewValidator(pairs []Pair) (*Validator, error) {
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("there are no delimiter pairs")
+	}
+
+	v := &Validator{
+		closerFor: make(map[rune]rune, len(pairs)),
+		isCloser:  make(map[rune]bool, len(pairs)),
+	}
+
+	used := make(map[rune]bool, 2*len(pairs))
+	for _, p := range pairs {
+		if p.Open == p.Close {
+			return nil, fmt.Errorf("the pair '%c%c' opens and closes with the same delimiter", p.Open, p.Close)
+		}
+
+		for _, r := range []rune{p.Open, p.Close} {
+			if used[r] {
+				return nil, fmt.Errorf("the delimiter '%c' is used by more than one pair", r)
+			}
+
+			used[r] = true
+		}
+
+		v.closerFor[p.Open] = p.Close
+		v.isCloser[p.Close] = true
+	}
+
+	return v, nil
+}
+
+// Validate() checks a single line. Any rune that isn't one of the delimiters is
+// ignored, so the line can contain other text.
+func (v *Validator) Validate(line string) Result {
+	// the stack holds the closing delimiters the open chunks are waiting for
+	expected := stack.NewStack[rune]()
+
+	column := 0
+	for _, r := range line {
+		column++
+
+		if closer, ok := v.closerFor[r]; ok {
+			expected.Push(closer)
+			continue
+		}
+
+		if !v.isCloser[r] {
+			continue
+		}
+
+		want, ok := expected.Pop()
+		if !ok || r != want {
+			return Result{Status: Corrupted, Found: r, Expected: want, Column: column}
+		}
+	}
+
+	if expected.IsEmpty() {
+		return Result{Status: Valid}
+	}
+
+	var completion strings.Builder
+	expected.Each(func(closer rune) bool {
+		completion.WriteRune(closer)
+		return true
+	})
+
+	return Result{Status: Incomplete, Completion: completion.String()}
+}
+
+// ValidateLines() checks each of the lines, returning the results in the same order.
+func (v *Validator) ValidateLines(lines []string) []Result {
+	results := make([]Result, len(lines))
+	for i, line := range lines {
+		results[i] = v.Validate(line)
+	}
+
+	return results
+}
@@ -0,0 +1,85 @@
+package chunks
+
+import (
+	"testing"
+)
+
+var sample = []string{
+	"[({(<(())[]>[[{[]{<()<>>",
+	"[(()[<>])]({[<{<<[]>>(",
+	"{([(<{}[<>[]}>{[]{[(<()>",
+	"(((({<>}<{<{<>}{[]{[]{}",
+	"[[<[([]))<([[{}[[()]]]",
+	"[{[{({}]{}}([{[{{{}}([]",
+	"{<[[]]>}<{[{[{[]{()[[[]",
+	"[<(<(<(<{}))><([]([]()",
+	"<{([([[(<>()){}]>(<<{{",
+	"<{([{{}}[<[[[<>{}]]]>[]]",
+}
+
+var validateTests = []struct {
+	line string
+	want Result
+}{
+	{"([]){<>}", Result{Status: Valid}},
+	{"{([(<{}[<>[]}>{[]{[(<()>", Result{Status: Corrupted, Found: '}', Expected: ']', Column: 13}},
+	{"[[<[([]))<([[{}[[()]]]", Result{Status: Corrupted, Found: ')', Expected: ']', Column: 9}},
+	{"())", Result{Status: Corrupted, Found: ')', Column: 3}},
+	{"[({(<(())[]>[[{[]{<()<>>", Result{Status: Incomplete, Completion: "}}]])})]"}},
+	{"<{([{{}}[<[[[<>{}]]]>[]]", Result{Status: Incomplete, Completion: "])}>"}},
+	// anything that isn't a delimiter is ignored
+	{"if (a[1] == b) {", Result{Status: Incomplete, Completion: "}"}},
+}
+
+// TestValidate() checks the status of lines from the puzzle and some of our own.
+func TestValidate(t *testing.T) {
+	v, err := NewValidator(BracketPairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range validateTests {
+		if got := v.Validate(test.line); got != test.want {
+			t.Errorf("Validate(%s):\nwant %+v\ngot  %+v\n", test.line, test.want, got)
+		}
+	}
+}
+
+// TestScoring() checks the puzzle's answers for the sample input.
+func TestScoring(t *testing.T) {
+	v, err := NewValidator(BracketPairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := v.ValidateLines(sample)
+
+	if got := SyntaxScoring.TotalCorrupted(results); got != 26397 {
+		t.Errorf("TotalCorrupted():\nwant %d\ngot  %d\n", 26397, got)
+	}
+
+	if got := SyntaxScoring.MiddleCompletion(results); got != 288957 {
+		t.Errorf("MiddleCompletion():\nwant %d\ngot  %d\n", 288957, got)
+	}
+}
+
+// TestCustomPairs() validates with a different set of delimiters.
+func TestCustomPairs(t *testing.T) {
+	v, err := NewValidator([]Pair{{Open: '«', Close: '»'}, {Open: '/', Close: '\\'}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Result{Status: Corrupted, Found: '»', Expected: '\\', Column: 4}
+	if got := v.Validate("«/ »"); got != want {
+		t.Errorf("Validate():\nwant %+v\ngot  %+v\n", want, got)
+	}
+
+	if _, err := NewValidator([]Pair{{Open: '(', Close: ')'}, {Open: ')', Close: '('}}); err == nil {
+		t.Errorf("NewValidator(): expected an error for a reused delimiter\n")
+	}
+
+	if _, err := NewValidator([]Pair{{Open: '|', Close: '|'}}); err == nil {
+		t.Errorf("NewValidator(): expected an error for a pair using the same delimiter\n")
+	}
+}
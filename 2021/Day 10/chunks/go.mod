@@ -0,0 +1,11 @@
+module sciencerocketry.com/chunks
+
+go 1.21
+
+require (
+    sciencerocketry.com/stack v0.0.0
+)
+
+replace (
+    sciencerocketry.com/stack => ../stack
+)
@@ -0,0 +1,69 @@
+package chunks
+
+import "sort"
+
+// Scoring is a table of the points awarded for corrupted and incomplete lines.
+type Scoring struct {
+	// Corrupted is the score for each closing delimiter that corrupts a line.
+	Corrupted map[rune]int
+	// Completion is the score for each closing delimiter in a completion string.
+	Completion map[rune]int
+	// Multiplier is applied to the running completion score before each delimiter's
+	// score is added to it.
+	Multiplier int
+}
+
+// SyntaxScoring is the scoring used by the navigation subsystem's syntax checker.
+var SyntaxScoring = Scoring{
+	Corrupted:  map[rune]int{')': 3, ']': 57, '}': 1197, '>': 25137},
+	Completion: map[rune]int{')': 1, ']': 2, '}': 3, '>': 4},
+	Multiplier: 5,
+}
+
+// Score() returns the score for a result - the corrupting delimiter's score for a
+// corrupted line, the completion string's score for an incomplete line and 0 for a
+// valid line. Delimiters missing from the table score 0.
+func (s Scoring) Score(r Result) int {
+	switch r.Status {
+	case Corrupted:
+		return s.Corrupted[r.Found]
+	case Incomplete:
+		score := 0
+		for _, closer := range r.Completion {
+			score = score*s.Multiplier + s.Completion[closer]
+		}
+
+		return score
+	}
+
+	return 0
+}
+
+// TotalCorrupted() returns the total score of the corrupted lines.
+func (s Scoring) TotalCorrupted(results []Result) (total int) {
+	for _, r := range results {
+		if r.Status == Corrupted {
+			total += s.Score(r)
+		}
+	}
+
+	return
+}
+
+// MiddleCompletion() returns the middle score of the incomplete lines once they are
+// sorted, or 0 if there are no incomplete lines.
+func (s Scoring) MiddleCompletion(results []Result) int {
+	var scores []int
+	for _, r := range results {
+		if r.Status == Incomplete {
+			scores = append(scores, s.Score(r))
+		}
+	}
+
+	if len(scores) == 0 {
+		return 0
+	}
+
+	sort.Ints(scores)
+	return scores[len(scores)/2]
+}
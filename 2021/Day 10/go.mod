@@ -3,9 +3,11 @@ module day10
 go 1.21
 
 require (
+    sciencerocketry.com/chunks v0.0.0
     sciencerocketry.com/stack v0.0.0
 )
 
 replace (
+    sciencerocketry.com/chunks => ./chunks
     sciencerocketry.com/stack => ./stack
-)
\ No newline at end of file
+)
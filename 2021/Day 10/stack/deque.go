@@ -0,0 +1,132 @@
+package stack
+
+// Deque is a double-ended queue of items of type T. Items can be added and removed at
+// either end in constant time. It is stored as a ring buffer that grows as needed.
+type Deque[T any] struct {
+	items []T
+	head  int
+	size  int
+}
+
+// NewDeque() creates an empty Deque.
+func NewDeque[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// index() converts a position relative to the front of the Deque into an index into
+// the ring buffer.
+func (d *Deque[T]) index(position int) int {
+	return (d.head + position) % len(d.items)
+}
+
+// grow() doubles the capacity of the ring buffer when it is full, unwrapping the
+// items so the front is at index 0.
+func (d *Deque[T]) grow() {
+	if d.size < len(d.items) {
+		return
+	}
+
+	items := make([]T, max(2*len(d.items), 8))
+	for i := 0; i < d.size; i++ {
+		items[i] = d.items[d.index(i)]
+	}
+
+	d.items = items
+	d.head = 0
+}
+
+// PushFront() adds an item to the front of the Deque.
+func (d *Deque[T]) PushFront(item T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.items)) % len(d.items)
+	d.items[d.head] = item
+	d.size++
+}
+
+// PushBack() adds an item to the back of the Deque.
+func (d *Deque[T]) PushBack(item T) {
+	d.grow()
+	d.items[d.index(d.size)] = item
+	d.size++
+}
+
+// PopFront() removes and returns the item at the front of the Deque. If the Deque is
+// empty, ok is false.
+func (d *Deque[T]) PopFront() (item T, ok bool) {
+	if d.size == 0 {
+		return item, false
+	}
+
+	var zero T
+	item = d.items[d.head]
+	d.items[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+
+	return item, true
+}
+
+// PopBack() removes and returns the item at the back of the Deque. If the Deque is
+// empty, ok is false.
+func (d *Deque[T]) PopBack() (item T, ok bool) {
+	if d.size == 0 {
+		return item, false
+	}
+
+	var zero T
+	last := d.index(d.size - 1)
+	item = d.items[last]
+	d.items[last] = zero
+	d.size--
+
+	return item, true
+}
+
+// Front() returns the item at the front of the Deque without removing it. If the
+// Deque is empty, ok is false.
+func (d *Deque[T]) Front() (item T, ok bool) {
+	return d.Peek(0)
+}
+
+// Back() returns the item at the back of the Deque without removing it. If the Deque
+// is empty, ok is false.
+func (d *Deque[T]) Back() (item T, ok bool) {
+	return d.Peek(d.size - 1)
+}
+
+// Peek() returns the item 'n' places behind the front of the Deque (Peek(0) is the
+// front) without removing it. If there is no such item, ok is false.
+func (d *Deque[T]) Peek(n int) (item T, ok bool) {
+	if n < 0 || n >= d.size {
+		return item, false
+	}
+
+	return d.items[d.index(n)], true
+}
+
+// Each() calls 'f' for each item from the front of the Deque to the back, stopping
+// early if 'f' returns false.
+func (d *Deque[T]) Each(f func(item T) bool) {
+	for i := 0; i < d.size; i++ {
+		if !f(d.items[d.index(i)]) {
+			return
+		}
+	}
+}
+
+// IsEmpty() reports whether the Deque has no items.
+func (d *Deque[T]) IsEmpty() bool {
+	return d.size == 0
+}
+
+// Size() returns the number of items in the Deque.
+func (d *Deque[T]) Size() int {
+	return d.size
+}
+
+// Clear() removes every item from the Deque.
+func (d *Deque[T]) Clear() {
+	d.items = nil
+	d.head = 0
+	d.size = 0
+}
@@ -0,0 +1,55 @@
+package stack
+
+// Queue is a first-in, first-out collection of items of type T.
+type Queue[T any] struct {
+	d Deque[T]
+}
+
+// NewQueue() creates an empty Queue.
+func NewQueue[T any]() *Queue[T] {
+	return &Queue[T]{}
+}
+
+// Enqueue() adds an item to the back of the Queue.
+func (q *Queue[T]) Enqueue(item T) {
+	q.d.PushBack(item)
+}
+
+// Dequeue() removes and returns the item at the front of the Queue. If the Queue is
+// empty, ok is false.
+func (q *Queue[T]) Dequeue() (item T, ok bool) {
+	return q.d.PopFront()
+}
+
+// Front() returns the item at the front of the Queue without removing it. If the
+// Queue is empty, ok is false.
+func (q *Queue[T]) Front() (item T, ok bool) {
+	return q.d.Front()
+}
+
+// Peek() returns the item 'n' places behind the front of the Queue (Peek(0) is the
+// front) without removing it. If there is no such item, ok is false.
+func (q *Queue[T]) Peek(n int) (item T, ok bool) {
+	return q.d.Peek(n)
+}
+
+// Each() calls 'f' for each item from the front of the Queue to the back, stopping
+// early if 'f' returns false.
+func (q *Queue[T]) Each(f func(item T) bool) {
+	q.d.Each(f)
+}
+
+// IsEmpty() reports whether the Queue has no items.
+func (q *Queue[T]) IsEmpty() bool {
+	return q.d.IsEmpty()
+}
+
+// Size() returns the number of items in the Queue.
+func (q *Queue[T]) Size() int {
+	return q.d.Size()
+}
+
+// Clear() removes every item from the Queue.
+func (q *Queue[T]) Clear() {
+	q.d.Clear()
+}
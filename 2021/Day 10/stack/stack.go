@@ -1,58 +1,90 @@
+// Package stack provides generic, type-safe containers: a last-in, first-out Stack,
+// a double-ended Deque and a first-in, first-out Queue. Methods that would otherwise
+// have nothing to return on an empty container return the zero value and false.
 package stack
 
-type Item interface{}
-
-type Stack struct {
-	items []Item
+// Stack is a last-in, first-out collection of items of type T.
+type Stack[T any] struct {
+	items []T
 }
 
-func NewStack() *Stack {
-	return &Stack{
+// NewStack() creates an empty Stack.
+func NewStack[T any]() *Stack[T] {
+	return &Stack[T]{
 		items: nil,
 	}
 }
 
-func NewStackWithData(items []Item) *Stack {
-	return &Stack{
+// NewStackWithData() creates a Stack holding 'items', where the last item is the top.
+func NewStackWithData[T any](items []T) *Stack[T] {
+	return &Stack[T]{
 		items: items,
 	}
 }
 
-func (stack *Stack) Push(item Item) {
+// Push() places an item on the top of the Stack.
+func (stack *Stack[T]) Push(item T) {
 	stack.items = append(stack.items, item)
 }
 
-func (stack *Stack) Pop() Item {
+// Pop() removes and returns the item on the top of the Stack. If the Stack is empty,
+// ok is false.
+func (stack *Stack[T]) Pop() (item T, ok bool) {
 	if len(stack.items) == 0 {
-		return nil
+		return item, false
 	}
 
 	lastItem := stack.items[len(stack.items)-1]
+
+	var zero T
+	stack.items[len(stack.items)-1] = zero
 	stack.items = stack.items[:len(stack.items)-1]
 
-	return lastItem
+	return lastItem, true
 }
 
-func (stack *Stack) Top() Item {
-	if len(stack.items) == 0 {
-		return nil
+// Top() returns the item on the top of the Stack without removing it. If the Stack is
+// empty, ok is false.
+func (stack *Stack[T]) Top() (item T, ok bool) {
+	return stack.Peek(0)
+}
+
+// Peek() returns the item 'n' places below the top of the Stack (Peek(0) is the top)
+// without removing it. If there is no such item, ok is false.
+func (stack *Stack[T]) Peek(n int) (item T, ok bool) {
+	if n < 0 || n >= len(stack.items) {
+		return item, false
 	}
 
-	return stack.items[len(stack.items)-1]
+	return stack.items[len(stack.items)-1-n], true
 }
 
-func (stack *Stack) IsEmpty() bool {
-	if len(stack.items) == 0 {
-		return true
+// Each() calls 'f' for each item from the top of the Stack to the bottom, stopping
+// early if 'f' returns false.
+func (stack *Stack[T]) Each(f func(item T) bool) {
+	for i := len(stack.items) - 1; i >= 0; i-- {
+		if !f(stack.items[i]) {
+			return
+		}
 	}
+}
+
+// Items() returns a copy of the items from the bottom of the Stack to the top.
+func (stack *Stack[T]) Items() []T {
+	return append([]T(nil), stack.items...)
+}
 
-	return false
+// IsEmpty() reports whether the Stack has no items.
+func (stack *Stack[T]) IsEmpty() bool {
+	return len(stack.items) == 0
 }
 
-func (stack *Stack) Size() int {
+// Size() returns the number of items in the Stack.
+func (stack *Stack[T]) Size() int {
 	return len(stack.items)
 }
 
-func (stack *Stack) Clear() {
+// Clear() removes every item from the Stack.
+func (stack *Stack[T]) Clear() {
 	stack.items = nil
 }
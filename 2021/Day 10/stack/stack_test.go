@@ -0,0 +1,126 @@
+package stack
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestStack() pushes and pops items, checking the (item, ok) results along the way.
+func TestStack(t *testing.T) {
+	s := NewStack[rune]()
+
+	if _, ok := s.Pop(); ok {
+		t.Errorf("Pop(): expected an empty Stack\n")
+	}
+
+	for _, r := range "([{<" {
+		s.Push(r)
+	}
+
+	if top, ok := s.Top(); !ok || top != '<' {
+		t.Errorf("Top():\nwant %c\ngot  %c (%t)\n", '<', top, ok)
+	}
+
+	if second, ok := s.Peek(1); !ok || second != '{' {
+		t.Errorf("Peek(1):\nwant %c\ngot  %c (%t)\n", '{', second, ok)
+	}
+
+	if _, ok := s.Peek(4); ok {
+		t.Errorf("Peek(4): expected no item\n")
+	}
+
+	var order []rune
+	s.Each(func(r rune) bool {
+		order = append(order, r)
+		return r != '['
+	})
+
+	if string(order) != "<{[" {
+		t.Errorf("Each():\nwant %s\ngot  %s\n", "<{[", string(order))
+	}
+
+	if !reflect.DeepEqual(s.Items(), []rune("([{<")) {
+		t.Errorf("Items():\nwant %v\ngot  %v\n", []rune("([{<"), s.Items())
+	}
+
+	for _, want := range "<{[(" {
+		if r, ok := s.Pop(); !ok || r != want {
+			t.Errorf("Pop():\nwant %c\ngot  %c (%t)\n", want, r, ok)
+		}
+	}
+
+	if !s.IsEmpty() || s.Size() != 0 {
+		t.Errorf("IsEmpty(): expected an empty Stack, size %d\n", s.Size())
+	}
+}
+
+// TestDeque() works both ends of a Deque past its initial capacity so the ring buffer
+// wraps and grows.
+func TestDeque(t *testing.T) {
+	d := NewDeque[int]()
+	var want []int
+
+	for i := 0; i < 20; i++ {
+		if i%2 == 0 {
+			d.PushBack(i)
+			want = append(want, i)
+		} else {
+			d.PushFront(i)
+			want = append([]int{i}, want...)
+		}
+	}
+
+	var got []int
+	d.Each(func(i int) bool {
+		got = append(got, i)
+		return true
+	})
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Each():\nwant %v\ngot  %v\n", want, got)
+	}
+
+	if back, ok := d.Back(); !ok || back != want[len(want)-1] {
+		t.Errorf("Back():\nwant %d\ngot  %d (%t)\n", want[len(want)-1], back, ok)
+	}
+
+	if third, ok := d.Peek(2); !ok || third != want[2] {
+		t.Errorf("Peek(2):\nwant %d\ngot  %d (%t)\n", want[2], third, ok)
+	}
+
+	for len(want) > 0 {
+		front, _ := d.PopFront()
+		back, _ := d.PopBack()
+		if front != want[0] || back != want[len(want)-1] {
+			t.Errorf("PopFront()/PopBack():\nwant %d, %d\ngot  %d, %d\n", want[0], want[len(want)-1], front, back)
+		}
+
+		want = want[1 : len(want)-1]
+	}
+
+	if _, ok := d.PopFront(); ok || !d.IsEmpty() {
+		t.Errorf("PopFront(): expected an empty Deque\n")
+	}
+}
+
+// TestQueue() checks that items leave a Queue in the order they were added.
+func TestQueue(t *testing.T) {
+	q := NewQueue[string]()
+	for _, s := range []string{"a", "b", "c"} {
+		q.Enqueue(s)
+	}
+
+	if front, ok := q.Front(); !ok || front != "a" || q.Size() != 3 {
+		t.Errorf("Front():\nwant a\ngot  %s (%t), size %d\n", front, ok, q.Size())
+	}
+
+	for _, want := range []string{"a", "b", "c"} {
+		if s, ok := q.Dequeue(); !ok || s != want {
+			t.Errorf("Dequeue():\nwant %s\ngot  %s (%t)\n", want, s, ok)
+		}
+	}
+
+	if _, ok := q.Dequeue(); ok {
+		t.Errorf("Dequeue(): expected an empty Queue\n")
+	}
+}
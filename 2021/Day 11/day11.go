@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -9,8 +10,11 @@ import (
 	"strconv"
 )
 
-const gridSize = 10
-const day1steps = 100
+// the number of steps whose flashes are totalled for Part 1
+const partOneSteps = 100
+
+// the number of steps to give up after when waiting for the octopi to synchronise
+const maxSteps = 100000
 
 type Octopus struct {
 	energy     int
@@ -36,53 +40,33 @@ func (o *Octopus) reset() {
 	}
 }
 
-func (o *Octopus) getAdjacent() (xs []int, ys []int) {
-	if o.x == 0 {
-		xs = append(xs, o.x)
-		xs = append(xs, o.x+1)
-	}
-
-	if o.x == gridSize-1 {
-		xs = append(xs, o.x)
-		xs = append(xs, o.x-1)
-	}
-
-	if len(xs) == 0 {
-		xs = append(xs, o.x-1)
-		xs = append(xs, o.x)
-		xs = append(xs, o.x+1)
-	}
-
-	if o.y == 0 {
-		ys = append(ys, o.y)
-		ys = append(ys, o.y+1)
-	}
-
-	if o.y == gridSize-1 {
-		ys = append(ys, o.y)
-		ys = append(ys, o.y-1)
+// getAdjacent() returns the x and y values of the octopi around this one (including
+// this one) that are inside a grid of the specified width and height
+func (o *Octopus) getAdjacent(width int, height int) (xs []int, ys []int) {
+	for x := max(o.x-1, 0); x <= min(o.x+1, width-1); x++ {
+		xs = append(xs, x)
 	}
 
-	if len(ys) == 0 {
-		ys = append(ys, o.y-1)
-		ys = append(ys, o.y)
-		ys = append(ys, o.y+1)
+	for y := max(o.y-1, 0); y <= min(o.y+1, height-1); y++ {
+		ys = append(ys, y)
 	}
 
 	return
 }
 
-func (o *Octopus) addEnergy(xOriginal int, yOriginal int, octopi [][]*Octopus) {
+// addEnergy() increases the energy of this octopus by one. When its energy reaches 10,
+// it flashes and adds energy to each of its neighbours in the grid.
+func (o *Octopus) addEnergy(xOriginal int, yOriginal int, g *OctopusGrid) {
 	o.energy++
 
 	if o.energy == 10 {
 		o.flashCount++
 		o.flashed = true
-		xs, ys := o.getAdjacent()
+		xs, ys := o.getAdjacent(g.width, g.height)
 		for _, y := range ys {
 			for _, x := range xs {
 				if !(xOriginal == x && yOriginal == y) {
-					octopi[y][x].addEnergy(xOriginal, yOriginal, octopi)
+					g.octopi[y][x].addEnergy(xOriginal, yOriginal, g)
 				}
 			}
 		}
@@ -97,10 +81,56 @@ func (o *Octopus) Print(w io.Writer) {
 	}
 }
 
-func PrintOctopi(w io.Writer, o [][]*Octopus) {
-	for i := 0; i < gridSize; i++ {
-		for j := 0; j < gridSize; j++ {
-			o[i][j].Print(w)
+// OctopusGrid is a rectangular grid of octopi whose size comes from the input.
+type OctopusGrid struct {
+	octopi        [][]*Octopus
+	width, height int
+
+	steps   int
+	flashes int
+
+	// synchronised is the first step where every octopus flashed, or 0 if they haven't
+	synchronised int
+
+	// CaptureFrames records a Frame after every step when it is set
+	CaptureFrames bool
+	frames        []Frame
+}
+
+// NewOctopusGrid() parses the input into an OctopusGrid, where each line is a row of
+// single-digit energy levels. Every row must be the same length.
+func NewOctopusGrid(input []string) (*OctopusGrid, error) {
+	if len(input) == 0 || len(input[0]) == 0 {
+		return nil, fmt.Errorf("there are no octopi in the input")
+	}
+
+	g := &OctopusGrid{width: len(input[0]), height: len(input)}
+	g.octopi = make([][]*Octopus, g.height)
+
+	for y, line := range input {
+		if len(line) != g.width {
+			return nil, fmt.Errorf("row %d has %d octopi but the first row has %d", y+1, len(line), g.width)
+		}
+
+		g.octopi[y] = make([]*Octopus, g.width)
+		for x, character := range line {
+			energyValue, err := strconv.Atoi(string(character))
+			if err != nil {
+				return nil, fmt.Errorf("invalid conversion to int at %d,%d", x, y)
+			}
+
+			g.octopi[y][x] = NewOctopus(x, y, energyValue)
+		}
+	}
+
+	return g, nil
+}
+
+// Print() prints the energy level of each octopus in the grid.
+func (g *OctopusGrid) Print(w io.Writer) {
+	for i := 0; i < g.height; i++ {
+		for j := 0; j < g.width; j++ {
+			g.octopi[i][j].Print(w)
 		}
 		fmt.Fprintf(w, "\n")
 	}
@@ -121,9 +151,18 @@ func ReadFile(filename string) ([]string, error) {
 	return lines, nil
 }
 
+// main() prints the Part 1 and Part 2 solutions. It receives the name of the data file
+// containing the grid of octopus energy levels. The flags optionally export every step
+// of the simulation as text frames and/or an animated GIF:
+//
+//	day11 [-frames frames.txt] [-gif octopi.gif] [-scale 10] day11.txt
 func main() {
-	var inputFile string
-	inputFile = os.Args[1]
+	framesFile := flag.String("frames", "", "write each step as a text frame to this file")
+	gifFile := flag.String("gif", "", "write each step as a frame of an animated GIF to this file")
+	scale := flag.Int("scale", 10, "the width and height (in pixels) of each octopus in the GIF")
+	flag.Parse()
+
+	inputFile := flag.Arg(0)
 
 	fileContents, err := ReadFile(inputFile)
 	if err != nil {
@@ -136,62 +175,115 @@ func main() {
 		log.Fatal(fmt.Errorf("invalid input in %s", inputFile))
 	}
 
-	octopi := make([][]*Octopus, gridSize)
-	for i := range octopi {
-		octopi[i] = make([]*Octopus, gridSize)
+	g, err := NewOctopusGrid(fileContents)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	for y, line := range fileContents {
-		for x, character := range line {
-			energyValue, err := strconv.Atoi(string(character))
-			if err != nil {
-				log.Fatal(fmt.Errorf("invalid conversion to int at %d,%d", x, y))
-			}
+	g.CaptureFrames = *framesFile != "" || *gifFile != ""
 
-			octopi[y][x] = NewOctopus(x, y, energyValue)
+	totalFlashes := 0
+	for i := 0; i < partOneSteps; i++ {
+		totalFlashes += g.Step()
+	}
+
+	fmt.Printf("Part One - Total Flashes: %d\n", totalFlashes)
+
+	simultaneous, err := g.RunUntilSynchronised(maxSteps)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Part Two - All Flashed on Step %d\n", simultaneous)
+
+	if *framesFile != "" {
+		if err := writeFile(*framesFile, g.WriteTextFrames); err != nil {
+			log.Fatal(err)
 		}
 	}
 
-	totalFlashes, simultaneous := ProcessSteps(octopi)
-	fmt.Printf("Day One - Total Flashes: %d\n", totalFlashes)
-	fmt.Printf("Day Two - All Flashed on Step %d\n", simultaneous)
+	if *gifFile != "" {
+		err := writeFile(*gifFile, func(w io.Writer) error {
+			return g.WriteGIF(w, *scale)
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 }
 
-func ProcessSteps(octopi [][]*Octopus) (totalFlashes int, simultaneous int) {
-	step := 1
-	for {
-		stepFlashed := 0
+// writeFile() creates the named file and passes it to 'write'.
+func writeFile(name string, write func(w io.Writer) error) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
 
-		for i := 0; i < gridSize; i++ {
-			for j := 0; j < gridSize; j++ {
-				octopi[i][j].addEnergy(j, i, octopi)
-			}
-		}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
 
-		isSimultaneous := true
-		for i := 0; i < gridSize; i++ {
-			for j := 0; j < gridSize; j++ {
-				if octopi[i][j].flashed {
-					stepFlashed++
-				} else {
-					isSimultaneous = false
-				}
+	return file.Close()
+}
 
-				octopi[i][j].reset()
-			}
+// Step() advances the grid by a single step and returns the number of octopi that
+// flashed during it. Every octopus gains one energy, any octopus that exceeds 9
+// flashes (adding energy to its neighbours) and every octopus that flashed ends the
+// step with no energy.
+func (g *OctopusGrid) Step() int {
+	for i := 0; i < g.height; i++ {
+		for j := 0; j < g.width; j++ {
+			g.octopi[i][j].addEnergy(j, i, g)
 		}
+	}
 
-		if isSimultaneous && simultaneous == 0 {
-			simultaneous = step
-			break
-		}
+	stepFlashed := 0
+	for i := 0; i < g.height; i++ {
+		for j := 0; j < g.width; j++ {
+			if g.octopi[i][j].flashed {
+				stepFlashed++
+			}
 
-		if step <= day1steps {
-			totalFlashes += stepFlashed
+			g.octopi[i][j].reset()
 		}
+	}
+
+	g.steps++
+	g.flashes += stepFlashed
 
-		step++
+	if stepFlashed == g.width*g.height && g.synchronised == 0 {
+		g.synchronised = g.steps
 	}
 
-	return
+	if g.CaptureFrames {
+		g.frames = append(g.frames, g.snapshot(stepFlashed))
+	}
+
+	return stepFlashed
+}
+
+// Steps() returns the number of steps the grid has taken.
+func (g *OctopusGrid) Steps() int {
+	return g.steps
+}
+
+// Flashes() returns the total number of flashes over all of the steps taken.
+func (g *OctopusGrid) Flashes() int {
+	return g.flashes
+}
+
+// RunUntilSynchronised() steps the grid until every octopus flashes during the same
+// step and returns the number of the first such step, which may be one the grid has
+// already taken. An error is returned if the octopi haven't synchronised by step 'limit'.
+func (g *OctopusGrid) RunUntilSynchronised(limit int) (int, error) {
+	for g.synchronised == 0 && g.steps < limit {
+		g.Step()
+	}
+
+	if g.synchronised != 0 {
+		return g.synchronised, nil
+	}
+
+	return 0, fmt.Errorf("the octopi didn't synchronise within %d steps", limit)
 }
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"image/gif"
+	"strings"
+	"testing"
+)
+
+var sampleInput = []string{
+	"5483143223",
+	"2745854711",
+	"5264556173",
+	"6141336146",
+	"6357385478",
+	"4167524645",
+	"2176841721",
+	"6882881134",
+	"4846848554",
+	"5283751526",
+}
+
+// TestStep() validates the number of flashes after a number of steps (Part 1).
+func TestStep(t *testing.T) {
+	var tests = []struct {
+		steps        int
+		totalFlashes int
+	}{
+		{10, 204},
+		{100, 1656},
+	}
+
+	for _, test := range tests {
+		g, err := NewOctopusGrid(sampleInput)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		totalFlashes := 0
+		for i := 0; i < test.steps; i++ {
+			totalFlashes += g.Step()
+		}
+
+		if totalFlashes != test.totalFlashes || g.Flashes() != test.totalFlashes {
+			t.Errorf("Step() x %d:\nwant %d\ngot  %d (Flashes(): %d)\n", test.steps, test.totalFlashes, totalFlashes, g.Flashes())
+		}
+	}
+}
+
+// TestRunUntilSynchronised() validates the step where every octopus flashes (Part 2).
+func TestRunUntilSynchronised(t *testing.T) {
+	g, err := NewOctopusGrid(sampleInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	step, err := g.RunUntilSynchronised(1000)
+	if err != nil || step != 195 {
+		t.Errorf("RunUntilSynchronised():\nwant 195\ngot  %d (%v)\n", step, err)
+	}
+
+	g, _ = NewOctopusGrid(sampleInput)
+	if _, err := g.RunUntilSynchronised(100); err == nil {
+		t.Errorf("RunUntilSynchronised(100): expected an error\n")
+	}
+
+	// a grid that synchronises during Part 1's steps still reports that step afterwards
+	g, _ = NewOctopusGrid([]string{"99", "99"})
+	for i := 0; i < partOneSteps; i++ {
+		g.Step()
+	}
+
+	step, err = g.RunUntilSynchronised(1000)
+	if err != nil || step != 1 {
+		t.Errorf("RunUntilSynchronised() after %d steps:\nwant 1\ngot  %d (%v)\n", partOneSteps, step, err)
+	}
+}
+
+// TestFrames() checks the captured frames against the puzzle's example and that the
+// frames can be written as text and as a GIF.
+func TestFrames(t *testing.T) {
+	g, err := NewOctopusGrid(sampleInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g.CaptureFrames = true
+	g.Step()
+	g.Step()
+
+	want := `After step 2 (35 flashes):
+8807476555
+5089087054
+8597889608
+8485769600
+8700908800
+6600088989
+6800005943
+0000007456
+9000000876
+8700006848
+`
+
+	frames := g.Frames()
+	if len(frames) != 2 || frames[1].String() != want {
+		t.Fatalf("Frames():\nwant\n%s\ngot\n%v\n", want, frames)
+	}
+
+	var text strings.Builder
+	if err := g.WriteTextFrames(&text); err != nil || !strings.Contains(text.String(), want) {
+		t.Errorf("WriteTextFrames(): %v\n%s\n", err, text.String())
+	}
+
+	var b bytes.Buffer
+	if err := g.WriteGIF(&b, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	animation, err := gif.DecodeAll(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(animation.Image) != 2 || animation.Image[0].Bounds().Dx() != 30 {
+		t.Errorf("WriteGIF():\nwant 2 frames 30 pixels wide\ngot  %d frames %d pixels wide\n", len(animation.Image), animation.Image[0].Bounds().Dx())
+	}
+}
+
+// TestNewOctopusGridSize() checks that grids that aren't 10x10 are sized from the input
+// and that ragged input is rejected.
+func TestNewOctopusGridSize(t *testing.T) {
+	g, err := NewOctopusGrid([]string{"11111", "19991", "19191", "19991", "11111"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.width != 5 || g.height != 5 {
+		t.Errorf("NewOctopusGrid():\nwant 5x5\ngot  %dx%d\n", g.width, g.height)
+	}
+
+	// the puzzle's small example: 9 octopi flash on the first step
+	if totalFlashes := g.Step(); totalFlashes != 9 {
+		t.Errorf("Step():\nwant 9\ngot  %d\n", totalFlashes)
+	}
+
+	if _, err := NewOctopusGrid([]string{"123", "12"}); err == nil {
+		t.Errorf("NewOctopusGrid(): expected an error for ragged input\n")
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"strings"
+)
+
+// Frame is a snapshot of the energy levels in an OctopusGrid after a step. An energy
+// level of 0 means the octopus flashed during that step.
+type Frame struct {
+	Step    int
+	Flashes int
+	Energy  [][]int
+}
+
+// snapshot() captures the grid's current energy levels as a Frame.
+func (g *OctopusGrid) snapshot(flashes int) Frame {
+	f := Frame{Step: g.steps, Flashes: flashes, Energy: make([][]int, g.height)}
+	for y := range f.Energy {
+		f.Energy[y] = make([]int, g.width)
+		for x := range f.Energy[y] {
+			f.Energy[y][x] = g.octopi[y][x].energy
+		}
+	}
+
+	return f
+}
+
+// Frames() returns the Frames captured while CaptureFrames was set.
+func (g *OctopusGrid) Frames() []Frame {
+	return g.frames
+}
+
+// String() renders a Frame as text in the same style as the puzzle description.
+func (f Frame) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "After step %d (%d flashes):\n", f.Step, f.Flashes)
+	for _, row := range f.Energy {
+		for _, energy := range row {
+			b.WriteString(fmt.Sprint(energy))
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// WriteTextFrames() writes each captured Frame as text, separated by blank lines.
+func (g *OctopusGrid) WriteTextFrames(w io.Writer) error {
+	for _, f := range g.frames {
+		if _, err := fmt.Fprintln(w, f.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// the GIF palette - index 0 is a flashing octopus and the rest are energy levels 1-9
+// getting brighter as the octopus gets closer to flashing
+var framePalette = color.Palette{
+	color.RGBA{255, 255, 200, 255},
+	color.RGBA{5, 10, 40, 255},
+	color.RGBA{10, 20, 60, 255},
+	color.RGBA{15, 30, 80, 255},
+	color.RGBA{20, 45, 100, 255},
+	color.RGBA{25, 60, 120, 255},
+	color.RGBA{30, 75, 140, 255},
+	color.RGBA{35, 90, 160, 255},
+	color.RGBA{40, 110, 180, 255},
+	color.RGBA{45, 130, 200, 255},
+}
+
+// frameDelay is the time each frame of the GIF is shown (in 100ths of a second).
+const frameDelay = 10
+
+// WriteGIF() writes the captured Frames as an animated GIF where each octopus is a
+// 'scale' x 'scale' square of pixels.
+func (g *OctopusGrid) WriteGIF(w io.Writer, scale int) error {
+	if len(g.frames) == 0 {
+		return fmt.Errorf("there are no frames to write - set CaptureFrames before stepping the grid")
+	}
+
+	if scale <= 0 {
+		return fmt.Errorf("the scale must be positive (%d)", scale)
+	}
+
+	animation := &gif.GIF{}
+	bounds := image.Rect(0, 0, g.width*scale, g.height*scale)
+
+	for _, f := range g.frames {
+		img := image.NewPaletted(bounds, framePalette)
+		for y, row := range f.Energy {
+			for x, energy := range row {
+				index := uint8(min(energy, len(framePalette)-1))
+				for py := y * scale; py < (y+1)*scale; py++ {
+					for px := x * scale; px < (x+1)*scale; px++ {
+						img.SetColorIndex(px, py, index)
+					}
+				}
+			}
+		}
+
+		animation.Image = append(animation.Image, img)
+		animation.Delay = append(animation.Delay, frameDelay)
+	}
+
+	return gif.EncodeAll(w, animation)
+}
@@ -0,0 +1,3 @@
+module day11
+
+go 1.21
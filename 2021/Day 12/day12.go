@@ -25,39 +25,72 @@ func main() {
 		log.Fatal(fmt.Errorf("invalid input in %s", inputFile))
 	}
 
+	g, err := ParseInput(fileContents)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Nodes: %d\n", g.NodeCount())
+
+	t := NewTraverser(g)
+	fmt.Printf("Part One - Number Distinct Paths: %d\n", len(t.Paths()))
+
+	t.AllowRevisit = true
+	fmt.Printf("Part Two - Number Distinct Paths: %d\n", len(t.Paths()))
+}
+
+// ParseInput() builds the cave graph from the input, where each line (e.g. 'start-A')
+// connects two caves.
+func ParseInput(input []string) (graph.ItemGraph, error) {
 	var g graph.ItemGraph
 
-	for _, line := range fileContents {
-		path := strings.Split(line, "-")
-		start := path[0]
-		destination := path[1]
+	for i, line := range input {
+		start, destination, found := strings.Cut(line, "-")
+		if !found {
+			return g, fmt.Errorf("line %d: could not parse the connection '%s'", i+1, line)
+		}
 
 		g.AddEdge(start, destination)
 	}
 
-	fmt.Printf("Nodes: %d\n", g.NodeCount())
-
-	var visited []string
-	var path []string
+	return g, nil
+}
 
-	TraverseGraph(g, "start", path, visited, false)
-	fmt.Printf("Part One - Number Distinct Paths: %d\n", len(traversals))
+// Traverser finds the paths through a cave system from the Start cave to the End cave.
+// Big caves (named in upper case) can be visited any number of times but small caves
+// can only be visited once, unless AllowRevisit permits a single small cave to be
+// visited twice. The Start cave is never revisited.
+type Traverser struct {
+	graph        graph.ItemGraph
+	Start, End   string
+	AllowRevisit bool
+}
 
-	traversals, visited, path = nil, nil, nil
-	TraverseGraph(g, "start", path, visited, true)
-	fmt.Printf("Part Two - Number Distinct Paths: %d\n", len(traversals))
+// NewTraverser() creates a Traverser that goes from 'start' to 'end' in the specified
+// graph without revisiting any small caves.
+func NewTraverser(g graph.ItemGraph) *Traverser {
+	return &Traverser{graph: g, Start: "start", End: "end"}
 }
 
-var traversals [][]string
+// Paths() returns every distinct path through the cave system.
+func (t *Traverser) Paths() [][]string {
+	var traversals [][]string
+	t.traverse(t.Start, nil, nil, t.AllowRevisit, &traversals)
+
+	return traversals
+}
 
-func TraverseGraph(g graph.ItemGraph, currentNode string, currentPath []string, visited []string, allowAnotherSmallVisit bool) {
-	if currentNode == "start" && len(currentPath) > 0 {
+// traverse() visits 'currentNode' and follows each of its connections, appending each
+// path that reaches the End cave to 'traversals'.
+func (t *Traverser) traverse(currentNode string, currentPath []string, visited []string, allowAnotherSmallVisit bool, traversals *[][]string) {
+	if currentNode == t.Start && len(currentPath) > 0 {
 		return
 	}
 
-	if currentNode == "end" {
-		currentPath = append(currentPath, currentNode)
-		traversals = append(traversals, currentPath)
+	if currentNode == t.End {
+		path := make([]string, len(currentPath), len(currentPath)+1)
+		copy(path, currentPath)
+		*traversals = append(*traversals, append(path, currentNode))
 		return
 	}
 
@@ -75,8 +108,8 @@ func TraverseGraph(g graph.ItemGraph, currentNode string, currentPath []string,
 		visited = append(visited, currentNode)
 	}
 
-	for _, destination := range g.GetDestinations(currentNode) {
-		TraverseGraph(g, destination, currentPath, visited, allowAnotherSmallVisit)
+	for _, destination := range t.graph.GetDestinations(currentNode) {
+		t.traverse(destination, currentPath, visited, allowAnotherSmallVisit, traversals)
 	}
 }
 
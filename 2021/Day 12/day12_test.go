@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	"sciencerocketry.com/fileprocessing"
+)
+
+// TestPaths() validates the number of paths through the sample cave systems with and
+// without a small cave being revisited. Each Traverser is run twice to make sure
+// nothing carries over between runs.
+func TestPaths(t *testing.T) {
+	var tests = []struct {
+		inputFile    string
+		paths        int
+		revisitPaths int
+	}{
+		{"day12sample.txt", 10, 36},
+		{"day12samplelarger.txt", 19, 103},
+		{"day12samplelargest.txt", 226, 3509},
+	}
+
+	for _, test := range tests {
+		fileContents, err := fileprocessing.ReadFile(test.inputFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		g, err := ParseInput(fileContents)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		traverser := NewTraverser(g)
+		for i := 0; i < 2; i++ {
+			traverser.AllowRevisit = false
+			if paths := len(traverser.Paths()); paths != test.paths {
+				t.Errorf("Paths(): %s\nwant %d\ngot  %d\n", test.inputFile, test.paths, paths)
+			}
+
+			traverser.AllowRevisit = true
+			if paths := len(traverser.Paths()); paths != test.revisitPaths {
+				t.Errorf("Paths() with a revisit: %s\nwant %d\ngot  %d\n", test.inputFile, test.revisitPaths, paths)
+			}
+		}
+	}
+}
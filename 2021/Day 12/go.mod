@@ -8,6 +8,6 @@ require (
 )
 
 replace (
-    sciencerocketry.com/fileprocessing => ./fileprocessing
+    sciencerocketry.com/fileprocessing => ../fileprocessing
     sciencerocketry.com/graph => ./graph
 )
\ No newline at end of file
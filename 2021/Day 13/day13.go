@@ -5,17 +5,22 @@ import (
 	"io"
 	"log"
 	"os"
-	"strconv"
 	"strings"
 
 	"sciencerocketry.com/fileprocessing"
 )
 
+// Fold is an instruction to fold the paper along the line x=line or y=line.
 type Fold struct {
 	axis string
 	line int
 }
 
+// Point is the position of a dot on the paper.
+type Point struct {
+	X, Y int
+}
+
 func main() {
 	inputFile := os.Args[1]
 
@@ -30,190 +35,256 @@ func main() {
 		log.Fatal(fmt.Errorf("invalid input in %s", inputFile))
 	}
 
-	maxX, maxY := 0, 0
-	for _, line := range fileContents {
-		// get the grid length and width
-		if len(line) == 0 {
-			// the coordinates are complete
-			break
-		}
+	points, folds, err := ParseInput(fileContents)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-		data := strings.Split(line, ",")
-		x, _ := strconv.Atoi(data[0])
-		y, _ := strconv.Atoi(data[1])
+	if len(folds) == 0 {
+		log.Fatal(fmt.Errorf("there are no fold instructions in %s", inputFile))
+	}
 
-		if x > maxX {
-			maxX = x
-		}
+	paper := NewPaper(points)
 
-		if y > maxY {
-			maxY = y
-		}
+	partOne := paper.Fold(folds[0])
+	fmt.Printf("After first fold - %d dots\n\n", partOne.CountDots())
+
+	partTwo := paper
+	for _, f := range folds {
+		partTwo = partTwo.Fold(f)
+	}
+
+	code, err := Recognise(partTwo)
+	if err != nil {
+		// the letters couldn't all be read, so print them and let a human read them
+		fmt.Printf("%v\n\n", err)
 	}
 
+	fmt.Printf("The activation code is: %s\n\n", code)
+	partTwo.Print(os.Stdout)
+}
+
+// ParseInput() parses the input into the dots on the paper (e.g. '6,10'), which are
+// followed by a blank line and then the fold instructions (e.g. 'fold along y=7').
+func ParseInput(input []string) ([]Point, []Fold, error) {
+	var points []Point
 	var folds []Fold
 
-	// create a starting grid
-	coordinates := make([][]bool, maxY+1)
-	for i := 0; i < len(coordinates); i++ {
-		coordinates[i] = make([]bool, maxX+1)
+	sections := fileprocessing.Sections(input)
+	if len(sections) > 2 {
+		return nil, nil, sections[2].Errorf(0, "expected only the dots and the fold instructions")
 	}
 
-	isFoldsInput := false
-	for _, line := range fileContents {
-		if len(line) == 0 {
-			isFoldsInput = true
-			continue
-		}
+	if len(sections) > 0 {
+		dots := sections[0]
+		for i, line := range dots.Lines {
+			p, err := parsePoint(strings.TrimSpace(line))
+			if err != nil {
+				return nil, nil, dots.Errorf(i, "%w", err)
+			}
 
-		if !isFoldsInput {
-			// the current line is an x,y coordinate
-			data := strings.Split(line, ",")
-			x, _ := strconv.Atoi(data[0])
-			y, _ := strconv.Atoi(data[1])
-
-			coordinates[y][x] = true
-		} else {
-			// the current line is a fold instruction
-			data := strings.Split(line[11:], "=")
-			foldLine, _ := strconv.Atoi(data[1])
-
-			f := new(Fold)
-			f.axis = data[0]
-			f.line = foldLine
-			folds = append(folds, *f)
+			points = append(points, p)
 		}
 	}
 
-	partOne := FoldGrid(coordinates, folds[0].axis, folds[0].line)
-	fmt.Printf("After first fold - %d dots\n\n", CountDots(partOne))
+	if len(sections) > 1 {
+		instructions := sections[1]
+		for i, line := range instructions.Lines {
+			f, err := ParseFold(strings.TrimSpace(line))
+			if err != nil {
+				return nil, nil, instructions.Errorf(i, "%w", err)
+			}
 
-	partTwo := coordinates
-	for _, f := range folds {
-		partTwo = FoldGrid(partTwo, f.axis, f.line)
+			folds = append(folds, f)
+		}
 	}
 
-	fmt.Printf("The activation code is:\n")
-	PrintGrid(os.Stdout, partTwo)
+	return points, folds, nil
 }
 
-func FoldGrid(g [][]bool, axis string, line int) [][]bool {
-	gridSizeX, gridSizeY := len(g[0]), len(g)
+// pointPattern and foldPattern match the two kinds of line in the input.
+var pointPattern = fileprocessing.MustPattern("{x:int},{y:int}")
+var foldPattern = fileprocessing.MustPattern("fold along {axis}={line:int}")
 
-	xSize, ySize := 0, 0
-	xStart, yStart := 0, 0
+// parsePoint() parses an 'x,y' coordinate.
+func parsePoint(input string) (Point, error) {
+	var p Point
+	if err := pointPattern.Scan(input, &p.X, &p.Y); err != nil {
+		return Point{}, fmt.Errorf("could not parse the coordinate '%s': %w", input, err)
+	}
 
-	if axis == "x" {
-		// horizontal fold at line
-		left := line
-		right := gridSizeX - 1 - line
-		xSize = left
-		if left > right {
-			xStart = left - right
-		}
+	return p, nil
+}
 
-		if left <= right {
-			xStart = 0
-		}
+// ParseFold() parses a fold instruction such as 'fold along x=655'. Extra whitespace
+// is ignored and the axis can be upper or lower case.
+func ParseFold(input string) (Fold, error) {
+	var f Fold
+	if err := foldPattern.Scan(input, &f.axis, &f.line); err != nil {
+		return Fold{}, fmt.Errorf("'%s' isn't a fold instruction: %w", input, err)
+	}
 
-		ySize = gridSizeY
-	} else {
-		// vertical fold at line
-		upper := line
-		lower := gridSizeY - 1 - line
-		ySize = upper
+	f.axis = strings.ToLower(f.axis)
+	if f.axis != "x" && f.axis != "y" {
+		return Fold{}, fmt.Errorf("the fold instruction '%s' has an unknown axis '%s'", input, f.axis)
+	}
 
-		if upper > lower {
-			yStart = upper - lower
-		}
+	return f, nil
+}
 
-		if upper <= lower {
-			yStart = 0
-		}
+// Paper is a sparse set of the dots on the transparent paper. Only the dots are
+// stored, so the size of the paper doesn't matter.
+type Paper struct {
+	dots map[Point]bool
+}
 
-		xSize = gridSizeX
+// NewPaper() creates a Paper with a dot at each of the specified points.
+func NewPaper(points []Point) *Paper {
+	p := &Paper{dots: make(map[Point]bool, len(points))}
+	for _, point := range points {
+		p.dots[point] = true
 	}
 
-	// make a new destination grid with the size computed above
-	newGrid := make([][]bool, ySize)
-	for i := 0; i < len(newGrid); i++ {
-		newGrid[i] = make([]bool, xSize)
-	}
+	return p
+}
 
-	iterations := 0
-	if axis == "x" {
-		// fill in the early part of the new grid
-		for x := 0; x < xStart; x++ {
-			for y := 0; y < ySize; y++ {
-				newGrid[y][x] = g[y][x]
-			}
+// Fold() returns a new Paper that is the result of folding this one along the line
+// in 'f'. Dots past the line are reflected back over it (so the fold can be anywhere,
+// not just the middle of the paper) and dots on the line itself disappear into the
+// crease.
+func (p *Paper) Fold(f Fold) *Paper {
+	folded := &Paper{dots: make(map[Point]bool, len(p.dots))}
+
+	for point := range p.dots {
+		coordinate := &point.Y
+		if f.axis == "x" {
+			coordinate = &point.X
 		}
 
-		// fill in the overlap from the fold
-		for x := xStart; x < xSize; x++ {
-			left := line - iterations - 1
-			right := line + iterations + 1
-			for y := 0; y < ySize; y++ {
-				newGrid[y][xSize-iterations-1] = g[y][left] || g[y][right]
-			}
-
-			iterations++
+		if *coordinate == f.line {
+			continue
 		}
-	} else {
-		// axis == "y"
-		// fill in the early part of the new grid
-		for y := 0; y < yStart; y++ {
-			for x := 0; x < xSize; x++ {
-				newGrid[y][x] = g[y][x]
-			}
+
+		if *coordinate > f.line {
+			*coordinate = 2*f.line - *coordinate
 		}
 
-		// fill in the overlap from the fold
-		for y := yStart; y < ySize; y++ {
-			upper := line - iterations - 1
-			lower := line + iterations + 1
+		folded.dots[point] = true
+	}
 
-			for x := 0; x < xSize; x++ {
-				newGrid[ySize-iterations-1][x] = g[upper][x] || g[lower][x]
-			}
+	return folded
+}
 
-			iterations++
-		}
+// CountDots() returns the number of visible dots.
+func (p *Paper) CountDots() int {
+	return len(p.dots)
+}
+
+// bounds() returns the smallest rectangle that contains all of the dots and the top
+// left corner of the paper (0,0) - a letter might start with a blank column, so the
+// rectangle can't just start at the leftmost dot. Folds past the middle of the paper
+// can leave dots at negative coordinates, which extend the rectangle.
+func (p *Paper) bounds() (minPoint Point, maxPoint Point) {
+	for point := range p.dots {
+		minPoint.X, minPoint.Y = min(minPoint.X, point.X), min(minPoint.Y, point.Y)
+		maxPoint.X, maxPoint.Y = max(maxPoint.X, point.X), max(maxPoint.Y, point.Y)
 	}
 
-	return newGrid
+	return
 }
 
-func PrintGrid(w io.Writer, g [][]bool) {
-	verticalSize := len(g)
-	for y := 0; y < verticalSize; y++ {
-		horizontalSize := len(g[y])
-		for x := 0; x < horizontalSize; x++ {
-			if g[y][x] {
-				fmt.Fprintf(w, "#")
+// rows() renders the dots inside the paper's bounds as rows of '#' and ' ' characters.
+func (p *Paper) rows() []string {
+	if len(p.dots) == 0 {
+		return nil
+	}
+
+	minPoint, maxPoint := p.bounds()
+
+	var rows []string
+	var row strings.Builder
+	for y := minPoint.Y; y <= maxPoint.Y; y++ {
+		row.Reset()
+		for x := minPoint.X; x <= maxPoint.X; x++ {
+			if p.dots[Point{X: x, Y: y}] {
+				row.WriteByte('#')
 			} else {
-				fmt.Fprintf(w, " ")
+				row.WriteByte(' ')
 			}
 		}
 
-		fmt.Fprintf(w, "\n")
+		rows = append(rows, row.String())
+	}
+
+	return rows
+}
+
+// Print() prints the dots on the paper.
+func (p *Paper) Print(w io.Writer) {
+	for _, row := range p.rows() {
+		fmt.Fprintf(w, "%s\n", row)
 	}
 
 	fmt.Fprintf(w, "\n")
 }
 
-func CountDots(g [][]bool) int {
-	xSize := len(g[0])
-	ySize := len(g)
+// the dimensions of the letters the activation code is made of - each letter is
+// followed by a blank column
+const letterWidth = 4
+const letterHeight = 6
+
+// letters maps each of the known 4x6 block letters to its character.
+var letters = map[string]rune{
+	" ## |#  #|#  #|####|#  #|#  #": 'A',
+	"### |#  #|### |#  #|#  #|### ": 'B',
+	" ## |#  #|#   |#   |#  #| ## ": 'C',
+	"####|#   |### |#   |#   |####": 'E',
+	"####|#   |### |#   |#   |#   ": 'F',
+	" ## |#  #|#   |# ##|#  #| ###": 'G',
+	"#  #|#  #|####|#  #|#  #|#  #": 'H',
+	" ###|  # |  # |  # |  # | ###": 'I',
+	"  ##|   #|   #|   #|#  #| ## ": 'J',
+	"#  #|# # |##  |# # |# # |#  #": 'K',
+	"#   |#   |#   |#   |#   |####": 'L',
+	" ## |#  #|#  #|#  #|#  #| ## ": 'O',
+	"### |#  #|#  #|### |#   |#   ": 'P',
+	"### |#  #|#  #|### |# # |#  #": 'R',
+	" ###|#   |#   | ## |   #|### ": 'S',
+	"#  #|#  #|#  #|#  #|#  #| ## ": 'U',
+	"#   |#   | # #|  # |  # |  # ": 'Y',
+	"####|   #|  # | #  |#   |####": 'Z',
+}
 
-	numDots := 0
-	for y := 0; y < ySize; y++ {
-		for x := 0; x < xSize; x++ {
-			if g[y][x] {
-				numDots++
-			}
+// Recognise() reads the block letters formed by the dots on the paper. Any letter that
+// can't be recognised is returned as a '?' along with an error.
+func Recognise(p *Paper) (string, error) {
+	rows := p.rows()
+	if len(rows) != letterHeight {
+		return "", fmt.Errorf("the dots are %d rows high but letters are %d rows high", len(rows), letterHeight)
+	}
+
+	var code strings.Builder
+	var unknown []int
+
+	for start := 0; start < len(rows[0]); start += letterWidth + 1 {
+		glyph := make([]string, letterHeight)
+		for i, row := range rows {
+			// the last letter's trailing columns may be blank and outside the bounds
+			glyph[i] = fmt.Sprintf("%-*s", letterWidth, row[start:min(start+letterWidth, len(row))])
+		}
+
+		letter, ok := letters[strings.Join(glyph, "|")]
+		if !ok {
+			letter = '?'
+			unknown = append(unknown, code.Len()+1)
 		}
+
+		code.WriteRune(letter)
+	}
+
+	if len(unknown) > 0 {
+		return code.String(), fmt.Errorf("could not recognise the letters at positions %v", unknown)
 	}
 
-	return numDots
+	return code.String(), nil
 }
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"sciencerocketry.com/fileprocessing"
+)
+
+// TestFold() validates the number of dots after each fold of the sample.
+func TestFold(t *testing.T) {
+	fileContents, err := fileprocessing.ReadFile("day13sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	points, folds, err := ParseInput(fileContents)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(points) != 18 || len(folds) != 2 {
+		t.Fatalf("ParseInput():\nwant 18 points, 2 folds\ngot  %d points, %d folds\n", len(points), len(folds))
+	}
+
+	paper := NewPaper(points)
+	want := []int{17, 16}
+	for i, f := range folds {
+		paper = paper.Fold(f)
+		if paper.CountDots() != want[i] {
+			t.Errorf("Fold(%v):\nwant %d\ngot  %d\n", f, want[i], paper.CountDots())
+		}
+	}
+}
+
+// TestFoldPastMiddle() folds along a line closer to the start of the paper than the
+// end, which reflects dots to negative coordinates.
+func TestFoldPastMiddle(t *testing.T) {
+	paper := NewPaper([]Point{{0, 0}, {1, 0}, {5, 0}, {2, 0}})
+	folded := paper.Fold(Fold{axis: "x", line: 2})
+
+	for _, p := range []Point{{0, 0}, {1, 0}, {-1, 0}} {
+		if !folded.dots[p] {
+			t.Errorf("Fold(): missing dot %v in %v\n", p, folded.dots)
+		}
+	}
+
+	if folded.CountDots() != 3 {
+		t.Errorf("Fold():\nwant 3 dots\ngot  %d\n", folded.CountDots())
+	}
+
+	if paper.CountDots() != 4 {
+		t.Errorf("Fold() modified the original paper\n")
+	}
+}
+
+// TestParseFold() checks that fold instructions are parsed despite extra whitespace
+// and that invalid instructions are rejected.
+func TestParseFold(t *testing.T) {
+	var tests = []struct {
+		input string
+		fold  Fold
+		valid bool
+	}{
+		{"fold along x=655", Fold{axis: "x", line: 655}, true},
+		{"  fold along   Y = 7 ", Fold{axis: "y", line: 7}, true},
+		{"fold along z=7", Fold{}, false},
+		{"fold along y7", Fold{}, false},
+		{"fold x=3", Fold{}, false},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFold(test.input)
+		if (err == nil) != test.valid || f != test.fold {
+			t.Errorf("ParseFold(%s):\nwant %v (valid: %t)\ngot  %v (%v)\n", test.input, test.fold, test.valid, f, err)
+		}
+	}
+}
+
+// TestRecognise() reads a code made of every known letter.
+func TestRecognise(t *testing.T) {
+	var want strings.Builder
+	var rows [letterHeight]string
+
+	for glyph, letter := range letters {
+		want.WriteRune(letter)
+		for i, row := range strings.Split(glyph, "|") {
+			rows[i] += row + " "
+		}
+	}
+
+	var points []Point
+	for y, row := range rows {
+		for x, c := range row {
+			if c == '#' {
+				points = append(points, Point{X: x, Y: y})
+			}
+		}
+	}
+
+	code, err := Recognise(NewPaper(points))
+	if err != nil || code != want.String() {
+		t.Errorf("Recognise():\nwant %s\ngot  %s (%v)\n", want.String(), code, err)
+	}
+
+	// an unknown glyph is returned as a '?' with an error
+	code, err = Recognise(NewPaper(append(points, Point{X: 1, Y: 1}, Point{X: 2, Y: 2})))
+	if err == nil || code[0] != '?' {
+		t.Errorf("Recognise(): expected an unknown first letter\ngot  %s (%v)\n", code, err)
+	}
+}
+
+// TestParseInput() checks CRLF line endings, trailing blank lines and the line number
+// given for a bad fold instruction.
+func TestParseInput(t *testing.T) {
+	points, folds, err := ParseInput(strings.Split("6,10\r\n0,14\r\n\r\nfold along y=7\r\n\r\n\r\n", "\n"))
+	if err != nil || len(points) != 2 || len(folds) != 1 {
+		t.Errorf("ParseInput():\nwant 2 points, 1 fold\ngot  %v %v (%v)\n", points, folds, err)
+	}
+
+	_, _, err = ParseInput([]string{"6,10", "", "fold along y=7", "fold along z=2"})
+	if err == nil || !strings.HasPrefix(err.Error(), "line 4:") {
+		t.Errorf("ParseInput():\nwant an error on line 4\ngot  %v\n", err)
+	}
+}
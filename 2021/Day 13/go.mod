@@ -7,5 +7,5 @@ require (
 )
 
 replace (
-    sciencerocketry.com/fileprocessing => ./fileprocessing
+    sciencerocketry.com/fileprocessing => ../fileprocessing
 )
\ No newline at end of file
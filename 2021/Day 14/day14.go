@@ -1,34 +1,29 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
-	"os"
+	"math/big"
+	"sort"
+	"strconv"
 	"strings"
 
+	"sciencerocketry.com/bigmatrix"
 	"sciencerocketry.com/fileprocessing"
 )
 
-var characterCount = map[byte]uint64{}
-
-type Pair struct {
-	pair  string
-	count uint64
-}
-
-func NewPair(p string, c uint64) *Pair {
-	var pair = new(Pair)
-	pair.pair = p
-	pair.count = c
-	return pair
-}
-
-func resetGlobals() {
-	characterCount = map[byte]uint64{}
-}
-
+// main() prints the difference between the most and least common elements after
+// each of the specified number of steps. It receives the name of the data file
+// containing the polymer template and the pair insertion rules.
+//
+//	day14 [-steps 10,40] [-matrix] day14.txt
 func main() {
-	inputFile := os.Args[1]
+	steps := flag.String("steps", "10,40", "comma-separated list of the number of steps to apply")
+	useMatrix := flag.Bool("matrix", false, "use matrix exponentiation (faster for a huge number of steps)")
+	flag.Parse()
+
+	inputFile := flag.Arg(0)
 
 	fileContents, err := fileprocessing.ReadFile(inputFile)
 	if err != nil {
@@ -41,103 +36,251 @@ func main() {
 		log.Fatal(fmt.Errorf("invalid input in %s", inputFile))
 	}
 
-	template := fileContents[0]
-	rules := make(map[string]string)
+	template, rules, err := ParseInput(fileContents)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s, err := NewSimulator(template, rules)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	for _, line := range fileContents[2:] {
-		rule := strings.Split(line, " -> ")
-		rules[rule[0]] = rule[1]
+	if *useMatrix {
+		s.Mode = MatrixExponentiation
 	}
 
 	fmt.Printf("Template:       %s\n", template)
 
-	iterations := 10
-	ApplyInsertionRules(template, rules, iterations)
-	fmt.Printf("Part One - (Day 10) Most Common - Least Common = %d\n", SubtractLeastCommonFromMostCommon())
+	for _, step := range strings.Split(*steps, ",") {
+		iterations, err := strconv.Atoi(strings.TrimSpace(step))
+		if err != nil {
+			log.Fatal(fmt.Errorf("invalid number of steps: %s", step))
+		}
+
+		counts, err := s.Run(iterations)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("(Step %d) Most Common - Least Common = %s\n", iterations, SubtractLeastCommonFromMostCommon(counts))
+	}
+}
+
+// ParseInput() splits the input into the polymer template (the first line) and the
+// pair insertion rules (e.g. 'CH -> B') that follow the blank line.
+func ParseInput(input []string) (string, map[string]string, error) {
+	if len(input) == 0 {
+		return "", nil, fmt.Errorf("there is no polymer template")
+	}
+
+	template := strings.TrimSpace(input[0])
+	rules := make(map[string]string)
+
+	for i, line := range input[1:] {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		pair, insertion, found := strings.Cut(line, "->")
+		if !found {
+			return "", nil, fmt.Errorf("line %d: could not parse the rule '%s'", i+2, line)
+		}
 
-	resetGlobals()
+		rules[strings.TrimSpace(pair)] = strings.TrimSpace(insertion)
+	}
+
+	return template, rules, nil
+}
+
+// Mode specifies how a Simulator applies the insertion rules.
+type Mode int
+
+const (
+	// Iterative applies the rules one step at a time, which is O(steps).
+	Iterative Mode = iota
+	// MatrixExponentiation raises the pair transition matrix to the number of steps by
+	// repeated squaring, which is O(log(steps)) and suits a huge number of steps.
+	MatrixExponentiation
+)
 
-	iterations = 40
-	ApplyInsertionRules(template, rules, iterations)
-	fmt.Printf("Part Two - (Day 40) Most Common - Least Common = %d\n", SubtractLeastCommonFromMostCommon())
+// Simulator applies pair insertion rules to a polymer template. The polymer itself
+// is never built - only the number of times each pair of adjacent elements occurs
+// is tracked, and those counts are big integers so the polymer can grow without
+// overflowing.
+//
+// A rule maps a pair of elements to the elements inserted between them. The puzzle
+// inserts a single element, but any number of elements can be inserted.
+type Simulator struct {
+	template string
+	rules    map[string]string
+	Mode     Mode
 }
 
-func ApplyInsertionRules(template string, rules map[string]string, iterations int) {
+// NewSimulator() creates an Iterative Simulator for the specified template and rules.
+func NewSimulator(template string, rules map[string]string) (*Simulator, error) {
 	if len(template) == 0 {
-		return
+		return nil, fmt.Errorf("the polymer template is empty")
+	}
+
+	for pair, insertion := range rules {
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("the rule '%s -> %s' doesn't start with a pair of elements", pair, insertion)
+		}
+
+		if len(insertion) == 0 {
+			return nil, fmt.Errorf("the rule for '%s' doesn't insert anything", pair)
+		}
+	}
+
+	return &Simulator{template: template, rules: rules}, nil
+}
+
+// Run() applies the insertion rules the specified number of times and returns how many
+// of each element the resulting polymer contains.
+func (s *Simulator) Run(steps int) (map[byte]*big.Int, error) {
+	if steps < 0 {
+		return nil, fmt.Errorf("the number of steps can't be negative (%d)", steps)
+	}
+
+	pairs := map[string]*big.Int{}
+	for i := 0; i < len(s.template)-1; i++ {
+		addCount(pairs, s.template[i:i+2], big.NewInt(1))
+	}
+
+	if s.Mode == MatrixExponentiation {
+		pairs = s.runMatrix(pairs, steps)
+	} else {
+		for i := 0; i < steps; i++ {
+			pairs = s.step(pairs)
+		}
+	}
+
+	// every element is the first element of exactly one pair apart from the last
+	// element of the polymer, which never changes because insertions happen between
+	// elements
+	counts := map[byte]*big.Int{}
+	for pair, count := range pairs {
+		addCount(counts, pair[0], count)
+	}
+
+	addCount(counts, s.template[len(s.template)-1], big.NewInt(1))
+
+	return counts, nil
+}
+
+// step() applies the insertion rules to each pair once.
+func (s *Simulator) step(pairs map[string]*big.Int) map[string]*big.Int {
+	next := map[string]*big.Int{}
+
+	for pair, count := range pairs {
+		for _, produced := range s.produces(pair) {
+			addCount(next, produced, count)
+		}
+	}
+
+	return next
+}
+
+// produces() returns the pairs that replace 'pair' after a step. A pair without a rule
+// stays as it is.
+func (s *Simulator) produces(pair string) []string {
+	insertion, ok := s.rules[pair]
+	if !ok {
+		return []string{pair}
+	}
+
+	elements := pair[0:1] + insertion + pair[1:2]
+
+	produced := make([]string, 0, len(elements)-1)
+	for i := 0; i < len(elements)-1; i++ {
+		produced = append(produced, elements[i:i+2])
+	}
+
+	return produced
+}
+
+// runMatrix() advances the pair counts by the specified number of steps using a matrix
+// where each column describes the pairs a single pair produces.
+func (s *Simulator) runMatrix(pairs map[string]*big.Int, steps int) map[string]*big.Int {
+	// find every pair that can ever appear so each one gets a row in the matrix
+	index := map[string]int{}
+	var names []string
+
+	var queue []string
+	for pair := range pairs {
+		queue = append(queue, pair)
 	}
 
-	var templatePairs = map[string]*Pair{}
-	var newPairs = map[string]*Pair{}
+	sort.Strings(queue)
+	for len(queue) > 0 {
+		pair := queue[0]
+		queue = queue[1:]
 
-	// divide into pairs
-	for i := 0; i < len(template)-1; i++ {
-		characterCount[template[i]]++
+		if _, found := index[pair]; found {
+			continue
+		}
 
-		var thisPair = new(Pair)
-		thisPair.pair = template[i : i+2]
-		thisPair.count = 1
+		index[pair] = len(names)
+		names = append(names, pair)
+		queue = append(queue, s.produces(pair)...)
+	}
 
-		if templatePairs[thisPair.pair] == nil {
-			templatePairs[thisPair.pair] = thisPair
-		} else {
-			templatePairs[thisPair.pair].count++
+	m := bigmatrix.New(len(names))
+	for column, pair := range names {
+		for _, produced := range s.produces(pair) {
+			row := index[produced]
+			m[row][column].Add(m[row][column], big.NewInt(1))
 		}
 	}
 
-	characterCount[template[len(template)-1]]++
-
-	for iteration := 0; iteration < iterations; iteration++ {
-		for key, pair := range templatePairs {
-			ruleResult, ok := rules[key]
-			if ok {
-				leftPair := key[0:1] + ruleResult
-				rightPair := ruleResult + key[1:2]
-
-				if _, exists := newPairs[leftPair]; !exists {
-					newPairs[leftPair] = NewPair(leftPair, pair.count)
-					characterCount[ruleResult[0]] += pair.count
-				} else {
-					characterCount[ruleResult[0]] += pair.count
-					newPairs[leftPair].count += pair.count
-				}
-
-				if _, exists := newPairs[rightPair]; !exists {
-					newPairs[rightPair] = NewPair(rightPair, pair.count)
-				} else {
-					newPairs[rightPair].count += pair.count
-				}
-			} else {
-				if _, exists := newPairs[pair.pair]; !exists {
-					newPairs[pair.pair] = NewPair(pair.pair, pair.count)
-				} else {
-					newPairs[pair.pair].count += pair.count
-				}
-			}
+	v := make([]*big.Int, len(names))
+	for i, pair := range names {
+		v[i] = new(big.Int)
+		if count, ok := pairs[pair]; ok {
+			v[i].Set(count)
 		}
+	}
+
+	v = m.Power(steps).Apply(v)
 
-		for key := range templatePairs {
-			delete(templatePairs, key)
+	next := map[string]*big.Int{}
+	for i, pair := range names {
+		if v[i].Sign() != 0 {
+			next[pair] = v[i]
 		}
+	}
+
+	return next
+}
 
-		templatePairs = newPairs
-		newPairs = map[string]*Pair{}
+// addCount() adds 'count' to the value stored in the map for 'key'.
+func addCount[K comparable](counts map[K]*big.Int, key K, count *big.Int) {
+	if _, exists := counts[key]; !exists {
+		counts[key] = new(big.Int)
 	}
+
+	counts[key].Add(counts[key], count)
 }
 
-func SubtractLeastCommonFromMostCommon() uint64 {
-	var mostCommonCharacter, leastCommonCharacter uint64
+// SubtractLeastCommonFromMostCommon() returns the quantity of the most common element
+// minus the quantity of the least common element.
+func SubtractLeastCommonFromMostCommon(counts map[byte]*big.Int) *big.Int {
+	var mostCommonCharacter, leastCommonCharacter *big.Int
 
-	for _, count := range characterCount {
-		if leastCommonCharacter == 0 || count < leastCommonCharacter {
+	for _, count := range counts {
+		if leastCommonCharacter == nil || count.Cmp(leastCommonCharacter) < 0 {
 			leastCommonCharacter = count
 		}
 
-		if count > mostCommonCharacter {
+		if mostCommonCharacter == nil || count.Cmp(mostCommonCharacter) > 0 {
 			mostCommonCharacter = count
 		}
+	}
 
+	if mostCommonCharacter == nil {
+		return new(big.Int)
 	}
 
-	return mostCommonCharacter - leastCommonCharacter
+	return new(big.Int).Sub(mostCommonCharacter, leastCommonCharacter)
 }
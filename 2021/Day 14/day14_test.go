@@ -0,0 +1,134 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+var sampleInput = `NNCB
+
+CH -> B
+HH -> N
+CB -> H
+NH -> C
+HB -> C
+HC -> B
+HN -> C
+NN -> C
+BH -> H
+NC -> B
+NB -> B
+BN -> B
+BB -> N
+BC -> B
+CC -> N
+CN -> C`
+
+// TestRun() validates the Part 1 and Part 2 sample answers in both simulation modes.
+func TestRun(t *testing.T) {
+	var tests = []struct {
+		steps  int
+		result string
+	}{
+		{10, "1588"},
+		{40, "2188189693529"},
+	}
+
+	template, rules, err := ParseInput(strings.Split(sampleInput, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []Mode{Iterative, MatrixExponentiation} {
+		s, err := NewSimulator(template, rules)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s.Mode = mode
+
+		for _, test := range tests {
+			counts, err := s.Run(test.steps)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// running twice must give the same answer since nothing is kept between runs
+			again, _ := s.Run(test.steps)
+
+			result := SubtractLeastCommonFromMostCommon(counts)
+			if result.String() != test.result || SubtractLeastCommonFromMostCommon(again).Cmp(result) != 0 {
+				t.Errorf("Run(%d) mode %d:\nwant %s\ngot  %s\n", test.steps, mode, test.result, result)
+			}
+		}
+	}
+}
+
+// TestRunMultipleInsertions() validates rules that insert more than one element by
+// comparing the counts against a polymer that is built in full.
+func TestRunMultipleInsertions(t *testing.T) {
+	template := "ABA"
+	rules := map[string]string{"AB": "CC", "BA": "A", "CA": "BAB"}
+
+	polymer := template
+	for step := 0; step <= 4; step++ {
+		for _, mode := range []Mode{Iterative, MatrixExponentiation} {
+			s, err := NewSimulator(template, rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s.Mode = mode
+
+			counts, err := s.Run(step)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, element := range []byte("ABC") {
+				want := int64(strings.Count(polymer, string(element)))
+				got := int64(0)
+				if count, ok := counts[element]; ok {
+					got = count.Int64()
+				}
+
+				if want != got {
+					t.Errorf("Run(%d) mode %d element %c:\nwant %d\ngot  %d\n", step, mode, element, want, got)
+				}
+			}
+		}
+
+		var next strings.Builder
+		for i := 0; i < len(polymer)-1; i++ {
+			next.WriteByte(polymer[i])
+			next.WriteString(rules[polymer[i:i+2]])
+		}
+
+		next.WriteByte(polymer[len(polymer)-1])
+		polymer = next.String()
+	}
+}
+
+// TestRunHugeNumberOfSteps() checks that both modes agree well past the point where
+// the counts overflow a 64-bit integer.
+func TestRunHugeNumberOfSteps(t *testing.T) {
+	template, rules, err := ParseInput(strings.Split(sampleInput, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewSimulator(template, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iterative, _ := s.Run(500)
+
+	s.Mode = MatrixExponentiation
+	matrix, _ := s.Run(500)
+
+	a, b := SubtractLeastCommonFromMostCommon(iterative), SubtractLeastCommonFromMostCommon(matrix)
+	if a.Cmp(b) != 0 || a.BitLen() <= 64 {
+		t.Errorf("Run(500):\niterative %s\nmatrix    %s\n", a, b)
+	}
+}
@@ -3,9 +3,11 @@ module day14
 go 1.21
 
 require (
+    sciencerocketry.com/bigmatrix v0.0.0
     sciencerocketry.com/fileprocessing v0.0.0
 )
 
 replace (
-    sciencerocketry.com/fileprocessing => ./fileprocessing
-)
\ No newline at end of file
+    sciencerocketry.com/bigmatrix => ../bigmatrix
+    sciencerocketry.com/fileprocessing => ../fileprocessing
+)
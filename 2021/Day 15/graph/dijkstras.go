@@ -1,8 +1,8 @@
 package graph
 
 import (
+	"container/heap"
 	"math"
-	"sort"
 )
 
 // the code below follows the algorithm specified for Dijkstra's Shortest Path
@@ -10,65 +10,65 @@ import (
 // more information about Dijkstra's Shortest Path algorithm can be found at:
 // https://en.wikipedia.org/wiki/Dijkstra's_algorithm
 
+// ShortestPathFromOrigin() returns the total weight of the lightest path from 'origin'
+// to 'destination', or -1 if either node isn't in the graph.
 func (g *Graph) ShortestPathFromOrigin(origin int, destination int) int {
 	graphSize := g.NumNodes
 	if graphSize <= 1 {
 		return -1
 	}
 
-	if destination < 0 || destination >= graphSize {
+	return ShortestPath(g, origin, destination)
+}
+
+// ShortestPath() returns the total weight of the lightest path from 'origin' to
+// 'destination' in any graph, or -1 if either node isn't in the graph or there is no
+// path between them. The nodes still to visit are kept in a binary heap, and the
+// search stops as soon as the destination is reached.
+func ShortestPath(g Interface, origin int, destination int) int {
+	graphSize := g.Size()
+	if origin < 0 || origin >= graphSize || destination < 0 || destination >= graphSize {
 		return -1
 	}
 
-	visited := make([]bool, graphSize)
-	weights := make([]int, graphSize)
-	weights[origin] = 0
-	for i := 1; i < graphSize; i++ {
-		weights[i] = math.MaxInt
-		visited[i] = false
+	distances := make([]int, graphSize)
+	for i := range distances {
+		distances[i] = math.MaxInt
 	}
 
-	g.CalculateShortestPath(origin, weights, visited)
-
-	return weights[destination]
-}
-
-func (g *Graph) CalculateShortestPath(position int, distances []int, visited []bool) {
-	var edges PairList
-	edges = append(edges, Pair{position, 0})
-
-	for len(edges) > 0 {
-		position = edges[0].Key
+	distances[origin] = 0
 
-		for _, edge := range g.Edges[position] {
-			if !visited[edge.To] {
-				if distances[edge.To] == math.MaxInt {
-					edges = append(edges, Pair{edge.To, edge.Weight})
-				}
-
-				weight := distances[position] + edge.Weight
-				if weight < distances[edge.To] {
-					distances[edge.To] = weight
-				}
-			}
+	queue := &PairList{{Key: origin, Value: 0}}
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(Pair)
+		if current.Value > distances[current.Key] {
+			// a shorter path to this node has already been visited
+			continue
 		}
 
-		visited[position] = true
-		edges = edges[1:]
-
-		for i := 0; i < len(edges); i++ {
-			edges[i].Value = distances[edges[i].Key]
+		if current.Key == destination {
+			return current.Value
 		}
 
-		sort.Sort(edges)
+		g.EachEdge(current.Key, func(e Edge) {
+			weight := current.Value + e.Weight
+			if weight < distances[e.To] {
+				distances[e.To] = weight
+				heap.Push(queue, Pair{Key: e.To, Value: weight})
+			}
+		})
 	}
+
+	return -1
 }
 
+// Pair is a node (Key) and the weight of the path to it (Value).
 type Pair struct {
 	Key   int
 	Value int
 }
 
+// PairList is a min-heap of Pairs ordered by Value, for use with container/heap.
 type PairList []Pair
 
 func (p PairList) Len() int {
@@ -82,3 +82,15 @@ func (p PairList) Swap(i, j int) {
 func (p PairList) Less(i, j int) bool {
 	return p[i].Value < p[j].Value
 }
+
+func (p *PairList) Push(x any) {
+	*p = append(*p, x.(Pair))
+}
+
+func (p *PairList) Pop() any {
+	old := *p
+	last := old[len(old)-1]
+	*p = old[:len(old)-1]
+
+	return last
+}
@@ -4,6 +4,14 @@ import (
 	"fmt"
 )
 
+// Interface is a directed graph with weights on the edges. The nodes are numbered from
+// 0 to Size()-1. The edges don't have to be stored - EachEdge() can work them out
+// when it is called.
+type Interface interface {
+	Size() int
+	EachEdge(from int, f func(e Edge))
+}
+
 // this models a directed graph with weights on the edges.
 type Graph struct {
 	NumNodes int
@@ -27,6 +35,18 @@ func (g *Graph) AddEdge(from int, to int, weight int) {
 	g.Edges[from] = append(g.Edges[from], Edge{From: from, To: to, Weight: weight})
 }
 
+// Size() returns the number of nodes in the graph.
+func (g *Graph) Size() int {
+	return g.NumNodes
+}
+
+// EachEdge() calls 'f' for each of the edges from the node 'from'.
+func (g *Graph) EachEdge(from int, f func(e Edge)) {
+	for _, e := range g.Edges[from] {
+		f(e)
+	}
+}
+
 // print a list of the nodes and the weights of the edges to the nodes they point to
 func (g *Graph) PrintAdjacentEdges() {
 	fmt.Println("Printing all edges in the graph.")
@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+)
+
+// the highest risk level - tiled risk levels above it wrap around to 1
+const maxRisk = 9
+
+// TiledGrid is a grid of risk levels made by repeating a tile across and down. Each
+// tile to the right or below adds one to the risk levels of the tile before it, and
+// levels above 9 wrap around to 1. Nodes are numbered row by row, and moving to an
+// adjacent node costs the risk level of the node being entered.
+//
+// The edges are worked out when they are asked for, so the memory used is the size
+// of a single tile however many times it is repeated.
+type TiledGrid struct {
+	tile                   [][]int
+	tileWidth, tileHeight  int
+	tilesAcross, tilesDown int
+	width, height          int
+}
+
+// NewTiledGrid() creates a TiledGrid from rows of digits that is repeated 'across'
+// times to the right and 'down' times below.
+func NewTiledGrid(rows []string, across int, down int) (*TiledGrid, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, fmt.Errorf("the tile is empty")
+	}
+
+	if across <= 0 || down <= 0 {
+		return nil, fmt.Errorf("the tile must be repeated at least once (%dx%d)", across, down)
+	}
+
+	g := &TiledGrid{
+		tile:        make([][]int, len(rows)),
+		tileWidth:   len(rows[0]),
+		tileHeight:  len(rows),
+		tilesAcross: across,
+		tilesDown:   down,
+	}
+
+	g.width, g.height = g.tileWidth*across, g.tileHeight*down
+
+	for y, row := range rows {
+		if len(row) != g.tileWidth {
+			return nil, fmt.Errorf("row %d is %d long instead of %d", y+1, len(row), g.tileWidth)
+		}
+
+		g.tile[y] = make([]int, g.tileWidth)
+		for x, c := range row {
+			if c < '1' || c > '9' {
+				return nil, fmt.Errorf("row %d: '%c' isn't a risk level from 1 to 9", y+1, c)
+			}
+
+			g.tile[y][x] = int(c - '0')
+		}
+	}
+
+	return g, nil
+}
+
+// Size() returns the number of nodes in the grid.
+func (g *TiledGrid) Size() int {
+	return g.width * g.height
+}
+
+// Width() returns the number of columns in the grid.
+func (g *TiledGrid) Width() int {
+	return g.width
+}
+
+// Height() returns the number of rows in the grid.
+func (g *TiledGrid) Height() int {
+	return g.height
+}
+
+// Risk() returns the risk level at column 'x', row 'y'.
+func (g *TiledGrid) Risk(x int, y int) int {
+	additive := x/g.tileWidth + y/g.tileHeight
+	risk := g.tile[y%g.tileHeight][x%g.tileWidth] + additive
+
+	return (risk-1)%maxRisk + 1
+}
+
+// EachEdge() calls 'f' for the edges to the nodes left, above, right and below 'from'.
+func (g *TiledGrid) EachEdge(from int, f func(e Edge)) {
+	x, y := from%g.width, from/g.width
+
+	if x > 0 {
+		f(Edge{From: from, To: from - 1, Weight: g.Risk(x-1, y)})
+	}
+
+	if y > 0 {
+		f(Edge{From: from, To: from - g.width, Weight: g.Risk(x, y-1)})
+	}
+
+	if x+1 < g.width {
+		f(Edge{From: from, To: from + 1, Weight: g.Risk(x+1, y)})
+	}
+
+	if y+1 < g.height {
+		f(Edge{From: from, To: from + g.width, Weight: g.Risk(x, y+1)})
+	}
+}
+
+// Print() prints the risk levels of the whole grid.
+func (g *TiledGrid) Print(w io.Writer) {
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			fmt.Fprintf(w, "%d", g.Risk(x, y))
+		}
+
+		fmt.Fprintf(w, "\n")
+	}
+}
@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"testing"
+)
+
+var sample = []string{
+	"1163751742",
+	"1381373672",
+	"2136511328",
+	"3694931569",
+	"7463417111",
+	"1319128137",
+	"1359912421",
+	"3125421639",
+	"1293138521",
+	"2311944581",
+}
+
+var shortestPathTests = []struct {
+	tiles int
+	want  int
+}{
+	{1, 40},
+	{5, 315},
+	{50, 3075},
+}
+
+// TestShortestPath() checks the lowest total risk of the example tiled various ways.
+func TestShortestPath(t *testing.T) {
+	for _, test := range shortestPathTests {
+		g, err := NewTiledGrid(sample, test.tiles, test.tiles)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := ShortestPath(g, 0, g.Size()-1); got != test.want {
+			t.Errorf("ShortestPath(%dx%d):\nwant %d\ngot  %d\n", test.tiles, test.tiles, test.want, got)
+		}
+	}
+}
+
+// TestRisk() checks that the tiled risk levels wrap around from 9 to 1.
+func TestRisk(t *testing.T) {
+	g, err := NewTiledGrid([]string{"8"}, 5, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]int{
+		{8, 9, 1, 2, 3},
+		{9, 1, 2, 3, 4},
+		{1, 2, 3, 4, 5},
+		{2, 3, 4, 5, 6},
+		{3, 4, 5, 6, 7},
+	}
+
+	for y, row := range want {
+		for x, risk := range row {
+			if got := g.Risk(x, y); got != risk {
+				t.Errorf("Risk(%d, %d):\nwant %d\ngot  %d\n", x, y, risk, got)
+			}
+		}
+	}
+}
+
+// TestExplicitGraph() checks that an explicit Graph gives the same answer as the
+// TiledGrid it was built from.
+func TestExplicitGraph(t *testing.T) {
+	tiled, err := NewTiledGrid(sample, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	explicit := NewGraph(tiled.Size())
+	for node := 0; node < tiled.Size(); node++ {
+		tiled.EachEdge(node, func(e Edge) { explicit.AddEdge(e.From, e.To, e.Weight) })
+	}
+
+	if got, want := explicit.ShortestPathFromOrigin(0, explicit.NumNodes-1), ShortestPath(tiled, 0, tiled.Size()-1); got != want {
+		t.Errorf("ShortestPathFromOrigin():\nwant %d\ngot  %d\n", want, got)
+	}
+}
@@ -5,13 +5,16 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"math"
 	"os"
 	"strconv"
+	"strings"
 
-	"fileprocessing"
+	"sciencerocketry.com/fileprocessing"
 )
 
 // main() prints the Part 1 and Part 2 solutions. It receives a single
@@ -21,18 +24,19 @@ import (
 func main() {
 	inputFile := os.Args[1]
 
-	fileContents, err := fileprocessing.ReadFile(inputFile)
+	file, err := os.Open(inputFile)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	numLines := len(fileContents)
-	if numLines <= 0 {
-		// invalid input
-		log.Fatal(fmt.Errorf("invalid input in %s", inputFile))
+	defer file.Close()
+
+	transmission, err := readTransmission(file, maxTransmissionSize)
+	if err != nil {
+		log.Fatal(fmt.Errorf("%s: %w", inputFile, err))
 	}
 
-	binaryString := convertHexStringToBinaryString(fileContents[0])
+	binaryString := convertHexStringToBinaryString(transmission)
 
 	p := new(Packet)
 	_ = p.New(binaryString)
@@ -41,6 +45,31 @@ func main() {
 	fmt.Printf("Part 2 - Calculated result of the packet payload: %d\n", p.CalculateResult())
 }
 
+// maxTransmissionSize is the longest transmission, in hexadecimal digits, that's read.
+// It's far longer than any puzzle input, but stops a file that isn't a transmission
+// from being read into memory as a single line.
+const maxTransmissionSize = 16 * 1024 * 1024
+
+// readTransmission() reads the transmission, which is the first line of 'r' that isn't
+// blank. The line can be any length up to 'maxSize' bytes, or any length at all if
+// 'maxSize' is 0.
+func readTransmission(r io.Reader, maxSize int) (string, error) {
+	lr := fileprocessing.NewLineReader(r)
+	lr.MaxLineSize = maxSize
+
+	for lr.Scan() {
+		if transmission := strings.TrimSpace(lr.Text()); len(transmission) > 0 {
+			return transmission, nil
+		}
+	}
+
+	if err := lr.Err(); err != nil {
+		return "", err
+	}
+
+	return "", errors.New("there's no transmission")
+}
+
 // These are the different type IDs a Packet can specify.
 const (
 	PacketSum          int64 = 0
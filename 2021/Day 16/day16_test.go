@@ -1,6 +1,12 @@
 package main
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"sciencerocketry.com/fileprocessing"
+)
 
 // TestPacketVersionSums() tests for Part 1 of the Day 16 assignment.
 func TestPacketVersionSums(t *testing.T) {
@@ -58,3 +64,22 @@ func TestPacketExpressions(t *testing.T) {
 		}
 	}
 }
+
+// TestReadTransmission() reads a transmission far longer than a default bufio.Scanner
+// allows a line to be, and one that's longer than the limit.
+func TestReadTransmission(t *testing.T) {
+	long := strings.Repeat("8A004A801A8002F478", 8*1024)
+
+	transmission, err := readTransmission(strings.NewReader("\n"+long+"\r\n"), maxTransmissionSize)
+	if err != nil || transmission != long {
+		t.Errorf("readTransmission():\nwant a transmission %d long\ngot  %d long and %v\n", len(long), len(transmission), err)
+	}
+
+	if _, err := readTransmission(strings.NewReader(long), len(long)-1); !errors.Is(err, fileprocessing.ErrLineTooLong) {
+		t.Errorf("readTransmission() with a limit of %d:\nwant %v\ngot  %v\n", len(long)-1, fileprocessing.ErrLineTooLong, err)
+	}
+
+	if _, err := readTransmission(strings.NewReader("\n\n"), maxTransmissionSize); err == nil {
+		t.Errorf("readTransmission() of blank lines: expected an error\n")
+	}
+}
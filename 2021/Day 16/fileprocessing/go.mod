@@ -1,3 +0,0 @@
-module sciencerocketry.com/fileprocessing
-
-go 1.21
@@ -4,13 +4,16 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"os"
 	"strconv"
 	"strings"
 
-	"fileprocessing"
+	"sciencerocketry.com/fileprocessing"
 )
 
 const (
@@ -23,10 +26,12 @@ type TargetArea struct {
 	Ymin, Ymax int
 }
 
-// main() prints the Part 1 and Part 2 solutions. It receives a single
-// argument specifying the name of the data file containing the hexidecimal
-// string input, which is converted to a binary string and then used to
-// calculate the Part 1 and Part 2 solutions.
+// main() prints the Part 1 and Part 2 solutions. It receives an argument
+// specifying the name of the data file containing the target area. Two
+// optional arguments specify an initial x and y velocity whose trajectory
+// is rendered as ASCII art after the solutions are printed.
+//
+//	day17 day17.txt [xVelocity yVelocity]
 func main() {
 	inputFile := os.Args[1]
 
@@ -42,11 +47,27 @@ func main() {
 	}
 
 	ta := ParseInput(fileContents[0])
-	launchers := FindAllIntersectingPaths(ta)
-	maxHeight, maxHeightLauncher := FindMaximumHeight(launchers)
 
-	fmt.Printf("Part 1 - (Max Height): %d using intial velocity (%d, %d)\n", maxHeight, maxHeightLauncher.xVelocity, maxHeightLauncher.yVelocity)
-	fmt.Printf("Part 2 - Number of Paths: %d\n", len(launchers))
+	solver, err := NewSolver(ta)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	result := solver.Solve()
+
+	fmt.Printf("Part 1 - (Max Height): %d using intial velocity (%d, %d)\n", result.MaxHeight, result.Best.xVelocity, result.Best.yVelocity)
+	fmt.Printf("Part 2 - Number of Paths: %d\n", result.Hits)
+
+	if len(os.Args) > 3 {
+		xVelocity, errX := strconv.Atoi(os.Args[2])
+		yVelocity, errY := strconv.Atoi(os.Args[3])
+		if errX != nil || errY != nil {
+			log.Fatal(fmt.Errorf("invalid velocity (%s, %s)", os.Args[2], os.Args[3]))
+		}
+
+		fmt.Printf("\nTrajectory using initial velocity (%d, %d):\n", xVelocity, yVelocity)
+		RenderTrajectory(os.Stdout, xVelocity, yVelocity, ta)
+	}
 }
 
 // parseInput() parses the input data into a TargetArea structure. The input
@@ -76,25 +97,23 @@ type Launcher struct {
 	path                 []Coordinate
 }
 
-func absInt(n int) int {
-	if n < 0 {
-		return -n
-	}
-	return n
-}
-
 // FindAllIntersectingPaths() determines all the possible initial x and y velocities that
-// have paths that intersect with the specified TargetArea.
+// have paths that intersect with the specified TargetArea. Every path is stored, so
+// Solver is the better choice when only the number of paths is needed. No paths are
+// returned if the TargetArea can be hit by an unbounded number of velocities.
 func FindAllIntersectingPaths(ta TargetArea) []Launcher {
 	var paths []Launcher
 	var path []Coordinate
 
-	// determine an acceptible x and y range to check
-	xMin, xMax, yMin, yMax := 0, ta.Xmax, -1*absInt(ta.Ymin), absInt(ta.Ymin)
-	for x := xMin; x <= xMax; x++ {
+	bounds, err := CalculateVelocityBounds(ta)
+	if err != nil {
+		return nil
+	}
+
+	for x := bounds.XMin; x <= bounds.XMax; x++ {
 		var isHit bool
 
-		for y := yMin; y <= yMax; y++ {
+		for y := bounds.YMin; y <= bounds.YMax; y++ {
 			isHit, path = CalculatePath(x, y, ta)
 			if isHit {
 				// this path hit the TargetArea
@@ -174,3 +193,282 @@ func FindMaximumHeight(launchers []Launcher) (int, Launcher) {
 
 	return maxHeight, maxHeightLauncher
 }
+
+// ErrUnbounded is returned when a TargetArea can be hit by an unlimited number of
+// initial velocities. That happens when the probe can stall horizontally inside the
+// target while the target spans the launcher's height - a probe fired straight up
+// with any y velocity falls back through y=0 and lands in the target.
+var ErrUnbounded = errors.New("the target area can be hit by an unbounded number of velocities")
+
+// VelocityBounds is the inclusive range of initial x and y velocities that can
+// possibly hit a TargetArea. Any velocity outside of it is guaranteed to miss.
+type VelocityBounds struct {
+	XMin, XMax int
+	YMin, YMax int
+}
+
+// CalculateVelocityBounds() derives the VelocityBounds for a TargetArea without
+// simulating any trajectories (apart from a short scan when the target spans y=0).
+//
+// Along the x axis the probe travels at most triangle(v) = v*(v+1)/2 from the
+// launcher before drag stops it, so the slowest useful velocity is the smallest v
+// that reaches the near edge and the fastest is the far edge (reached in one step).
+//
+// Along the y axis a probe fired upward with velocity v returns to y=0 with velocity
+// -(v+1), so for a target below the launcher the next step must not pass the bottom
+// of the target. For a target above the launcher the probe has to climb at least as
+// high as the bottom of the target but its first step can't overshoot the top.
+func CalculateVelocityBounds(ta TargetArea) (VelocityBounds, error) {
+	var b VelocityBounds
+
+	switch {
+	case ta.Xmin > 0:
+		b.XMin, b.XMax = minimumVelocityToReach(ta.Xmin), ta.Xmax
+	case ta.Xmax < 0:
+		b.XMin, b.XMax = ta.Xmin, -minimumVelocityToReach(-ta.Xmax)
+	default:
+		// the target spans x=0, so the probe can be fired in either direction
+		b.XMin, b.XMax = ta.Xmin, ta.Xmax
+	}
+
+	switch {
+	case ta.Ymax < 0:
+		b.YMin, b.YMax = ta.Ymin, -ta.Ymin-1
+	case ta.Ymin > 0:
+		b.YMin, b.YMax = minimumVelocityToReach(ta.Ymin), ta.Ymax
+	default:
+		// the target spans y=0 - a probe launched upward with any velocity comes back
+		// down through y=0, so the limit comes from how long the probe can stay
+		// inside the target's x range
+		steps, stalls := maximumStepsInRange(b.XMin, b.XMax, ta)
+		if stalls {
+			return b, ErrUnbounded
+		}
+
+		// for a velocity v above the target, every position over the steps 1..2v is at
+		// least v high, so the earliest possible hit is at step 2v+1
+		b.YMin, b.YMax = ta.Ymin, ta.Ymax
+		if limit := steps / 2; limit > b.YMax {
+			b.YMax = limit
+		}
+	}
+
+	return b, nil
+}
+
+// triangle() returns the n-th triangular number (1 + 2 + ... + n), which is the total
+// distance a probe moves along an axis when its velocity decreases by one each step.
+func triangle(n int) int {
+	return n * (n + 1) / 2
+}
+
+// minimumVelocityToReach() returns the smallest velocity v where triangle(v) reaches
+// the (positive) distance specified.
+func minimumVelocityToReach(distance int) int {
+	v := int(math.Sqrt(float64(2 * distance)))
+	for v > 0 && triangle(v-1) >= distance {
+		v--
+	}
+
+	for triangle(v) < distance {
+		v++
+	}
+
+	return v
+}
+
+// maximumStepsInRange() returns the last step at which a probe launched with any of the
+// x velocities in [xMin, xMax] can be inside the target's x range. If a probe can come
+// to a stop inside that range, stalls is true and the number of steps is meaningless.
+func maximumStepsInRange(xMin, xMax int, ta TargetArea) (steps int, stalls bool) {
+	for v := xMin; v <= xMax; v++ {
+		stop := triangle(absInt(v))
+		if v < 0 {
+			stop = -stop
+		}
+
+		if ta.Xmin <= stop && stop <= ta.Xmax {
+			return 0, true
+		}
+
+		x, velocity := 0, v
+		for step := 1; velocity != 0; step++ {
+			x += velocity
+			velocity -= sign(velocity)
+
+			if ta.Xmin <= x && x <= ta.Xmax && step > steps {
+				steps = step
+			}
+		}
+	}
+
+	return steps, false
+}
+
+// Fire() launches a probe with the specified velocities and reports whether it is
+// within the TargetArea after any step, along with the highest y position it reached
+// on the way. The trajectory isn't stored - the simulation stops as soon as the probe
+// hits the target or can no longer reach it.
+func Fire(xVelocity, yVelocity int, ta TargetArea) (hit bool, maxHeight int) {
+	x, y := 0, 0
+	for {
+		x, y, xVelocity, yVelocity = step(x, y, xVelocity, yVelocity)
+
+		if y > maxHeight {
+			maxHeight = y
+		}
+
+		if ta.Xmin <= x && x <= ta.Xmax && ta.Ymin <= y && y <= ta.Ymax {
+			return true, maxHeight
+		}
+
+		if isHopeless(x, y, xVelocity, yVelocity, ta) {
+			return false, maxHeight
+		}
+	}
+}
+
+// step() moves a probe from its x,y position by a single step, applying drag and gravity
+// to the velocities afterward.
+func step(x, y, xVelocity, yVelocity int) (int, int, int, int) {
+	x += xVelocity
+	y += yVelocity
+	xVelocity += sign(xVelocity) * Drag
+	yVelocity += Gravity
+
+	return x, y, xVelocity, yVelocity
+}
+
+// isHopeless() determines whether a probe at the specified position and velocity can
+// never be inside the TargetArea again. Drag never reverses the x velocity and gravity
+// only ever pulls the probe down, so a probe that is past the target (or below it and
+// falling) stays that way.
+func isHopeless(x, y, xVelocity, yVelocity int, ta TargetArea) bool {
+	if y < ta.Ymin && yVelocity <= 0 {
+		return true
+	}
+
+	if x > ta.Xmax && xVelocity >= 0 {
+		return true
+	}
+
+	if x < ta.Xmin && xVelocity <= 0 {
+		return true
+	}
+
+	return false
+}
+
+// Solver counts the initial velocities that hit a TargetArea, limiting the search to
+// the VelocityBounds derived for the target.
+type Solver struct {
+	Target TargetArea
+	Bounds VelocityBounds
+}
+
+// Result is what a Solver found: the number of velocities that hit the target and
+// the velocity that gets the probe the highest while still hitting it.
+type Result struct {
+	Hits      int
+	MaxHeight int
+	Best      Launcher
+}
+
+// NewSolver() creates a Solver for the specified TargetArea. ErrUnbounded is returned
+// if there are infinitely many velocities that hit the target.
+func NewSolver(ta TargetArea) (*Solver, error) {
+	bounds, err := CalculateVelocityBounds(ta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Solver{Target: ta, Bounds: bounds}, nil
+}
+
+// Solve() fires a probe with every velocity inside the Solver's bounds and returns the
+// number of hits and the highest trajectory. No paths are stored along the way.
+func (s *Solver) Solve() Result {
+	var r Result
+
+	for x := s.Bounds.XMin; x <= s.Bounds.XMax; x++ {
+		for y := s.Bounds.YMin; y <= s.Bounds.YMax; y++ {
+			hit, height := Fire(x, y, s.Target)
+			if !hit {
+				continue
+			}
+
+			if r.Hits == 0 || height > r.MaxHeight {
+				r.MaxHeight = height
+				r.Best = Launcher{xVelocity: x, yVelocity: y}
+			}
+
+			r.Hits++
+		}
+	}
+
+	return r
+}
+
+// RenderTrajectory() draws the trajectory of a probe launched with the specified
+// velocities in the same style as the puzzle description: 'S' is the launcher, 'T'
+// is the TargetArea and '#' is the probe's position after each step. The trajectory
+// ends when the probe hits the target or can no longer reach it.
+func RenderTrajectory(w io.Writer, xVelocity, yVelocity int, ta TargetArea) {
+	positions := map[Coordinate]bool{}
+
+	xMin, xMax := min(0, ta.Xmin), max(0, ta.Xmax)
+	yMin, yMax := min(0, ta.Ymin), max(0, ta.Ymax)
+
+	x, y := 0, 0
+	for {
+		x, y, xVelocity, yVelocity = step(x, y, xVelocity, yVelocity)
+		positions[Coordinate{x: x, y: y}] = true
+
+		xMin, xMax = min(xMin, x), max(xMax, x)
+		yMin, yMax = min(yMin, y), max(yMax, y)
+
+		if ta.Xmin <= x && x <= ta.Xmax && ta.Ymin <= y && y <= ta.Ymax {
+			break
+		}
+
+		if isHopeless(x, y, xVelocity, yVelocity, ta) {
+			break
+		}
+	}
+
+	var row strings.Builder
+	for y := yMax; y >= yMin; y-- {
+		row.Reset()
+		for x := xMin; x <= xMax; x++ {
+			switch {
+			case x == 0 && y == 0:
+				row.WriteRune('S')
+			case positions[Coordinate{x: x, y: y}]:
+				row.WriteRune('#')
+			case ta.Xmin <= x && x <= ta.Xmax && ta.Ymin <= y && y <= ta.Ymax:
+				row.WriteRune('T')
+			default:
+				row.WriteRune('.')
+			}
+		}
+
+		fmt.Fprintln(w, row.String())
+	}
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
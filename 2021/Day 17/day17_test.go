@@ -1,6 +1,9 @@
 package main
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 // TestParseTargetArea() tests the input parser that describes the target area.
 func TestParseTargetArea(t *testing.T) {
@@ -68,3 +71,103 @@ func TestFindDistinctInitialVelocityValues(t *testing.T) {
 		}
 	}
 }
+
+// TestCalculateVelocityBounds() validates the velocity ranges derived for a target area.
+func TestCalculateVelocityBounds(t *testing.T) {
+	var tests = []struct {
+		target TargetArea
+		bounds VelocityBounds
+	}{
+		{TargetArea{Xmin: 20, Xmax: 30, Ymin: -10, Ymax: -5}, VelocityBounds{XMin: 6, XMax: 30, YMin: -10, YMax: 9}},
+		{TargetArea{Xmin: 117, Xmax: 164, Ymin: -140, Ymax: -89}, VelocityBounds{XMin: 15, XMax: 164, YMin: -140, YMax: 139}},
+		{TargetArea{Xmin: -30, Xmax: -20, Ymin: -10, Ymax: -5}, VelocityBounds{XMin: -30, XMax: -6, YMin: -10, YMax: 9}},
+		{TargetArea{Xmin: 20, Xmax: 30, Ymin: 5, Ymax: 10}, VelocityBounds{XMin: 6, XMax: 30, YMin: 3, YMax: 10}},
+	}
+
+	for _, test := range tests {
+		bounds, err := CalculateVelocityBounds(test.target)
+		if err != nil {
+			t.Errorf("CalculateVelocityBounds(): %v\nunexpected error %v\n", test.target, err)
+			continue
+		}
+
+		if bounds != test.bounds {
+			t.Errorf("CalculateVelocityBounds(): %v\nwant %v\ngot  %v\n", test.target, test.bounds, bounds)
+		}
+	}
+
+	_, err := CalculateVelocityBounds(TargetArea{Xmin: 20, Xmax: 30, Ymin: -5, Ymax: 5})
+	if err != ErrUnbounded {
+		t.Errorf("CalculateVelocityBounds():\nwant %v\ngot  %v\n", ErrUnbounded, err)
+	}
+}
+
+// TestSolve() validates the Part 1 and Part 2 answers along with targets that sit behind
+// or above the launcher, comparing those against a brute force search.
+func TestSolve(t *testing.T) {
+	var tests = []struct {
+		target    TargetArea
+		hits      int
+		maxHeight int
+	}{
+		{TargetArea{Xmin: 20, Xmax: 30, Ymin: -10, Ymax: -5}, 112, 45},
+		{TargetArea{Xmin: 117, Xmax: 164, Ymin: -140, Ymax: -89}, 4110, 9730},
+		{TargetArea{Xmin: -30, Xmax: -20, Ymin: -10, Ymax: -5}, 112, 45},
+		{TargetArea{Xmin: 20, Xmax: 30, Ymin: 5, Ymax: 10}, bruteForceHits(TargetArea{Xmin: 20, Xmax: 30, Ymin: 5, Ymax: 10}), 55},
+		{TargetArea{Xmin: 11, Xmax: 14, Ymin: -3, Ymax: 2}, bruteForceHits(TargetArea{Xmin: 11, Xmax: 14, Ymin: -3, Ymax: 2}), 3},
+	}
+
+	for _, test := range tests {
+		solver, err := NewSolver(test.target)
+		if err != nil {
+			t.Errorf("NewSolver(): %v\nunexpected error %v\n", test.target, err)
+			continue
+		}
+
+		result := solver.Solve()
+		if result.Hits != test.hits || result.MaxHeight != test.maxHeight {
+			t.Errorf("Solve(): %v\nwant %d hits, height %d\ngot  %d hits, height %d\n", test.target, test.hits, test.maxHeight, result.Hits, result.MaxHeight)
+		}
+	}
+}
+
+// bruteForceHits() counts the hits for a target by firing every velocity in a range
+// that is far larger than the target needs.
+func bruteForceHits(ta TargetArea) int {
+	hits := 0
+	for x := -200; x <= 200; x++ {
+		for y := -200; y <= 200; y++ {
+			if hit, _ := Fire(x, y, ta); hit {
+				hits++
+			}
+		}
+	}
+
+	return hits
+}
+
+// TestRenderTrajectory() compares a rendered trajectory with the one in the readme.md.
+func TestRenderTrajectory(t *testing.T) {
+	want := `.............#....#............
+.......#..............#........
+...............................
+S........................#.....
+...............................
+...............................
+...........................#...
+...............................
+....................TTTTTTTTTTT
+....................TTTTTTTTTTT
+....................TTTTTTTT#TT
+....................TTTTTTTTTTT
+....................TTTTTTTTTTT
+....................TTTTTTTTTTT
+`
+
+	var b strings.Builder
+	RenderTrajectory(&b, 7, 2, TargetArea{Xmin: 20, Xmax: 30, Ymin: -10, Ymax: -5})
+
+	if b.String() != want {
+		t.Errorf("RenderTrajectory():\nwant\n%s\ngot\n%s\n", want, b.String())
+	}
+}
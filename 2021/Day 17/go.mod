@@ -3,9 +3,9 @@ module day17
 go 1.21
 
 require (
-    fileprocessing v0.0.0
+    sciencerocketry.com/fileprocessing v0.0.0
 )
 
 replace (
-    fileprocessing => ./fileprocessing
+    sciencerocketry.com/fileprocessing => ../fileprocessing
 )
\ No newline at end of file
@@ -10,7 +10,7 @@ import (
 	"strconv"
 	"strings"
 
-	"fileprocessing"
+	"sciencerocketry.com/fileprocessing"
 )
 
 // main() prints the Part 1 and Part 2 solutions. It receives a single
@@ -8,10 +8,8 @@ import (
 	"log"
 	"math"
 	"os"
-	"strconv"
-	"strings"
 
-	"fileprocessing"
+	"sciencerocketry.com/fileprocessing"
 )
 
 type Position struct {
@@ -24,40 +22,25 @@ type Scanner struct {
 	BeaconPermutations [][]Position
 }
 
-func (s *Scanner) New(id int, input []string) error {
-	if len(input) == 0 {
-		return fmt.Errorf("could not parse a new Scanner - input was empty")
+// headerPattern and beaconPattern match the two kinds of line in a scanner's section.
+var headerPattern = fileprocessing.MustPattern("--- scanner {id:int} ---")
+var beaconPattern = fileprocessing.MustPattern("{x:int},{y:int},{z:int}")
+
+// Scanner.New() parses a section of the input into a Scanner. The section's header
+// holds the scanner ID (e.g. '--- scanner 0 ---') and each line is a beacon's 'x,y,z'
+// position.
+func (s *Scanner) New(section fileprocessing.Section) error {
+	if err := headerPattern.Scan(section.Header, &s.ID); err != nil {
+		return section.Errorf(-1, "could not parse the scanner header: %w", err)
 	}
 
-	s.ID = id
-
-	for _, line := range input {
-		if strings.Contains(line, "scanner") {
-			// this is the first line
-			parsedHeader := strings.Split(line, " ")
-			scannerID, err := strconv.Atoi(parsedHeader[2])
-			if err != nil {
-				return err
-			}
-
-			s.ID = scannerID
-		} else {
-			coordinates := strings.Split(line, ",")
-			x, errX := strconv.Atoi(coordinates[0])
-			if errX != nil {
-				return errX
-			}
-			y, errY := strconv.Atoi(coordinates[1])
-			if errY != nil {
-				return errY
-			}
-			z, errZ := strconv.Atoi(coordinates[2])
-			if errZ != nil {
-				return errZ
-			}
-
-			s.Beacons = append(s.Beacons, Position{x, y, z})
+	for i, line := range section.Lines {
+		var p Position
+		if err := beaconPattern.Scan(line, &p.x, &p.y, &p.z); err != nil {
+			return section.Errorf(i, "could not parse the beacon position: %w", err)
 		}
+
+		s.Beacons = append(s.Beacons, p)
 	}
 
 	orientations := getPositionPermutations()
@@ -170,37 +153,28 @@ func main() {
 		log.Fatal(fmt.Errorf("invalid input in %s", inputFile))
 	}
 
-	scanners := parseInput(fileContents)
+	scanners, err := parseInput(fileContents)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	for i, scanner := range scanners {
 		fmt.Printf("Scanner %d\nNumber of Beacons: %d, Number of Permutations: %d\n%v\n", i, len(scanner.Beacons), len(scanner.BeaconPermutations)*len(scanner.BeaconPermutations[0]), scanner)
 	}
 }
 
-func parseInput(input []string) []Scanner {
+// parseInput() parses each section of the input into a Scanner.
+func parseInput(input []string) ([]Scanner, error) {
 	var scanners []Scanner
 
-	id, start := 0, 0
-	for i, line := range input {
-		if i == len(input)-1 {
-			// this is the last line of the input
-			var scanner *Scanner = new(Scanner)
-			scanner.New(id, input[start:i+1])
-			scanners = append(scanners, *scanner)
+	for _, section := range fileprocessing.HeadedSections(input) {
+		var scanner Scanner
+		if err := scanner.New(section); err != nil {
+			return nil, err
 		}
 
-		if len(line) == 0 {
-			// this is the last line of a section
-			var scanner *Scanner = new(Scanner)
-			scanner.New(id, input[start:i])
-			scanners = append(scanners, *scanner)
-
-			id++
-		}
-
-		if strings.Contains(line, "scanner") {
-			start = i
-		}
+		scanners = append(scanners, scanner)
 	}
 
-	return scanners
+	return scanners, nil
 }
@@ -0,0 +1,87 @@
+// Package bigmatrix provides square matrices of big integers that can be raised to
+// large powers, for the days that advance a set of counts by a huge number of steps.
+package bigmatrix
+
+import (
+	"math/big"
+)
+
+// Matrix is a square matrix of big integers where m[row][column].
+type Matrix [][]*big.Int
+
+// New() creates an n x n matrix of zeroes.
+func New(n int) Matrix {
+	m := make(Matrix, n)
+	for i := range m {
+		m[i] = make([]*big.Int, n)
+		for j := range m[i] {
+			m[i][j] = new(big.Int)
+		}
+	}
+
+	return m
+}
+
+// Identity() creates an n x n identity matrix.
+func Identity(n int) Matrix {
+	m := New(n)
+	for i := range m {
+		m[i][i].SetInt64(1)
+	}
+
+	return m
+}
+
+// Multiply() returns the product m * other.
+func (m Matrix) Multiply(other Matrix) Matrix {
+	result := New(len(m))
+	product := new(big.Int)
+
+	for i := range m {
+		for k := range m {
+			if m[i][k].Sign() == 0 {
+				continue
+			}
+
+			for j := range m {
+				product.Mul(m[i][k], other[k][j])
+				result[i][j].Add(result[i][j], product)
+			}
+		}
+	}
+
+	return result
+}
+
+// Power() raises the matrix to the n-th power by repeated squaring.
+func (m Matrix) Power(n int) Matrix {
+	result := Identity(len(m))
+	base := m
+
+	for n > 0 {
+		if n%2 == 1 {
+			result = result.Multiply(base)
+		}
+
+		base = base.Multiply(base)
+		n /= 2
+	}
+
+	return result
+}
+
+// Apply() returns the product of the matrix and the column vector 'v'.
+func (m Matrix) Apply(v []*big.Int) []*big.Int {
+	result := make([]*big.Int, len(m))
+	product := new(big.Int)
+
+	for i := range m {
+		result[i] = new(big.Int)
+		for j := range m[i] {
+			product.Mul(m[i][j], v[j])
+			result[i].Add(result[i], product)
+		}
+	}
+
+	return result
+}
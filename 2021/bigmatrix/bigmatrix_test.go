@@ -0,0 +1,41 @@
+package bigmatrix
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestPower() checks powers of the Fibonacci matrix, [[1 1] [1 0]]^n = [[F(n+1) F(n)] [F(n) F(n-1)]]
+func TestPower(t *testing.T) {
+	fibonacci := New(2)
+	fibonacci[0][0].SetInt64(1)
+	fibonacci[0][1].SetInt64(1)
+	fibonacci[1][0].SetInt64(1)
+
+	var tests = []struct {
+		n      int
+		result string
+	}{
+		{0, "0"},
+		{1, "1"},
+		{10, "55"},
+		{100, "354224848179261915075"},
+	}
+
+	for _, test := range tests {
+		value := fibonacci.Power(test.n)[0][1].String()
+		if value != test.result {
+			t.Errorf("Power(%d):\nwant %v\ngot  %v\n", test.n, test.result, value)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	m := Identity(3)
+	m[0][2].SetInt64(2)
+
+	v := m.Apply([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+	if v[0].Int64() != 7 || v[1].Int64() != 2 || v[2].Int64() != 3 {
+		t.Errorf("Apply():\nwant [7 2 3]\ngot  %v\n", v)
+	}
+}
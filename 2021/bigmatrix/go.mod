@@ -0,0 +1,3 @@
+module sciencerocketry.com/bigmatrix
+
+go 1.21
@@ -0,0 +1,151 @@
+// Package explain writes how each item of a puzzle's input contributed to the answers,
+// such as the score of each bingo board, either as text or as a JSON object per line.
+// The explanations go to their own writer, so they're never mixed up with the answers.
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Explanation is how a single item contributed to the answer to a part. Value is what
+// the item added to the answer, or the item's result when the answer is picked from
+// the items. Details are any extra lines, such as a drawing of the item. Exercise
+// names the puzzle, when one program solves several of them.
+type Explanation struct {
+	Exercise string   `json:"exercise,omitempty"`
+	Part     int      `json:"part"`
+	Item     string   `json:"item"`
+	Value    int      `json:"value"`
+	Reason   string   `json:"reason"`
+	Details  []string `json:"details,omitempty"`
+}
+
+// String() formats an explanation as text, e.g. 'part 1 - board 3: 4512 (won first)',
+// after the exercise's name if there is one, followed by the details indented on
+// their own lines.
+func (e Explanation) String() string {
+	var text strings.Builder
+	if e.Exercise != "" {
+		text.WriteString(e.Exercise + " ")
+	}
+
+	fmt.Fprintf(&text, "part %d - %s: %d (%s)", e.Part, e.Item, e.Value, e.Reason)
+
+	for _, line := range e.Details {
+		text.WriteString("\n    " + line)
+	}
+
+	return text.String()
+}
+
+// Explainer writes explanations as text or JSON. A nil Explainer throws them away, so
+// the solutions can explain themselves whether or not anyone asked.
+type Explainer struct {
+	w        io.Writer
+	json     bool
+	closer   io.Closer
+	exercise string
+}
+
+// New() creates an Explainer that writes to 'w' in the format, which is either 'text'
+// or 'json'.
+func New(w io.Writer, format string) (*Explainer, error) {
+	switch format {
+	case "text":
+		return &Explainer{w: w}, nil
+	case "json":
+		return &Explainer{w: w, json: true}, nil
+	}
+
+	return nil, fmt.Errorf("'%s' isn't a format for explanations - use 'text' or 'json'", format)
+}
+
+// Open() creates an Explainer from the values of a day's '-explain' and '-explain-to'
+// flags. No format means no explanations, so it returns nil. The explanations are
+// written to the named file, or to stderr if there's no filename. Close() the
+// Explainer when it's finished with.
+func Open(format string, filename string) (*Explainer, error) {
+	if format == "" {
+		return nil, nil
+	}
+
+	if filename == "" {
+		return New(os.Stderr, format)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := New(file, format)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	e.closer = file
+
+	return e, nil
+}
+
+// Close() closes the file the Explainer writes to, if Open() created one.
+func (e *Explainer) Close() error {
+	if e == nil || e.closer == nil {
+		return nil
+	}
+
+	return e.closer.Close()
+}
+
+// ForExercise() returns an Explainer that writes to the same place, labelling its
+// explanations with the exercise's name. Close() the original, not this one.
+func (e *Explainer) ForExercise(name string) *Explainer {
+	if e == nil {
+		return nil
+	}
+
+	forExercise := *e
+	forExercise.closer = nil
+	forExercise.exercise = name
+
+	return &forExercise
+}
+
+// Enabled() reports whether explanations are wanted. Solutions can use it to skip any
+// work that's only needed for an explanation.
+func (e *Explainer) Enabled() bool {
+	return e != nil
+}
+
+// Explain() writes an explanation.
+func (e *Explainer) Explain(ex Explanation) error {
+	if e == nil {
+		return nil
+	}
+
+	if ex.Exercise == "" {
+		ex.Exercise = e.exercise
+	}
+
+	if e.json {
+		return json.NewEncoder(e.w).Encode(ex)
+	}
+
+	_, err := fmt.Fprintln(e.w, ex)
+
+	return err
+}
+
+// Lines() captures what a Print() style function writes as lines, for use as the
+// Details of an Explanation.
+func Lines(print func(w io.Writer)) []string {
+	var text strings.Builder
+	print(&text)
+
+	return strings.Split(strings.TrimRight(text.String(), "\n"), "\n")
+}
@@ -0,0 +1,103 @@
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+var example = Explanation{Part: 1, Item: "board 3", Value: 4512, Reason: "won first", Details: []string{"14! 21!", " 7   4!"}}
+
+func TestText(t *testing.T) {
+	var output strings.Builder
+	e, err := New(&output, "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.Explain(example); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "part 1 - board 3: 4512 (won first)\n    14! 21!\n     7   4!\n"
+	if output.String() != expected {
+		t.Errorf("Explain():\nwant %q\ngot  %q\n", expected, output.String())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var output strings.Builder
+	e, err := New(&output, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := e.Explain(example); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Explain():\nwant a line for each explanation\ngot  %q\n", lines)
+	}
+
+	var ex Explanation
+	if err := json.Unmarshal([]byte(lines[1]), &ex); err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(ex) != fmt.Sprint(example) {
+		t.Errorf("Explain():\nwant %v\ngot  %v\n", example, ex)
+	}
+}
+
+func TestForExercise(t *testing.T) {
+	var output strings.Builder
+	e, err := New(&output, "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.ForExercise("Day 4").Explain(example); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "Day 4 part 1 - board 3: 4512 (won first)\n"
+	if first, _, _ := strings.Cut(output.String(), "    "); first != expected {
+		t.Errorf("ForExercise():\nwant %q\ngot  %q\n", expected, first)
+	}
+
+	var off *Explainer
+	if off.ForExercise("Day 4") != nil {
+		t.Errorf("ForExercise() on a nil Explainer:\nwant nil\ngot  an Explainer\n")
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	e, err := Open("", "")
+	if e != nil || err != nil || e.Enabled() {
+		t.Errorf("Open() with no format:\nwant nil\ngot  %v and %v\n", e, err)
+	}
+
+	if err := e.Explain(example); err != nil || e.Close() != nil {
+		t.Errorf("Explain() on a nil Explainer: expected nothing to happen\n")
+	}
+
+	if _, err := New(nil, "xml"); err == nil {
+		t.Errorf("New() with 'xml': expected an error\n")
+	}
+}
+
+func TestLines(t *testing.T) {
+	lines := Lines(func(w io.Writer) {
+		fmt.Fprintf(w, "a\nb\n")
+	})
+
+	if fmt.Sprint(lines) != "[a b]" {
+		t.Errorf("Lines():\nwant [a b]\ngot  %v\n", lines)
+	}
+}
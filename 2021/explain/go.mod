@@ -0,0 +1,3 @@
+module sciencerocketry.com/explain
+
+go 1.21
@@ -0,0 +1,42 @@
+package fileprocessing
+
+import (
+	"fmt"
+	"os"
+)
+
+// ReadFile() reads every line of the named file. Errors while reading, such as a line
+// that can't be read, are returned rather than silently cutting the file short.
+func ReadFile(filename string) ([]string, error) {
+	return ReadFileLimited(filename, 0)
+}
+
+// ReadFileLimited() reads every line of the named file like ReadFile(), but stops with
+// ErrLineTooLong at a line longer than 'maxLineSize' bytes. A 'maxLineSize' of 0 means
+// there's no limit.
+func ReadFileLimited(filename string, maxLineSize int) ([]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	var lines []string
+
+	lr := NewLineReader(file)
+	lr.MaxLineSize = maxLineSize
+	for lr.Scan() {
+		lines = append(lines, lr.Text())
+	}
+
+	if err := lr.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return lines, nil
+}
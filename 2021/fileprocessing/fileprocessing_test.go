@@ -0,0 +1,29 @@
+package fileprocessing
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestReadFileLimited() reads a file with a long line with and without a limit.
+func TestReadFileLimited(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "input.txt")
+	long := strings.Repeat("0123456789ABCDEF", 8*1024)
+	if err := os.WriteFile(filename, []byte(long+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadFileLimited(filename, len(long)-1); !errors.Is(err, ErrLineTooLong) {
+		t.Errorf("ReadFileLimited(%d):\nwant %v\ngot  %v\n", len(long)-1, ErrLineTooLong, err)
+	}
+
+	for _, maxLineSize := range []int{0, len(long)} {
+		lines, err := ReadFileLimited(filename, maxLineSize)
+		if err != nil || len(lines) != 1 || lines[0] != long {
+			t.Errorf("ReadFileLimited(%d):\nwant the line that's %d long\ngot  %d lines and %v\n", maxLineSize, len(long), len(lines), err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package fileprocessing
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestLongLines() reads lines far longer than a default bufio.Scanner allows.
+func TestLongLines(t *testing.T) {
+	long := strings.Repeat("0123456789ABCDEF", 64*1024)
+	input := "first\r\n" + long + "\nlast"
+
+	lines, err := ReadLines(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lines) != 3 || lines[0] != "first" || lines[1] != long || lines[2] != "last" {
+		t.Errorf("ReadLines():\nwant 3 lines with the second %d long\ngot  %d lines\n", len(long), len(lines))
+	}
+
+	lr := NewLineReader(strings.NewReader(input))
+	lr.MaxLineSize = 1024
+	for lr.Scan() {
+	}
+
+	if err := lr.Err(); !errors.Is(err, ErrLineTooLong) || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("Err():\nwant %v on line 2\ngot  %v\n", ErrLineTooLong, err)
+	}
+}
+
+// TestEnding() checks the line endings removed from each line.
+func TestEnding(t *testing.T) {
+	var endings []string
+
+	lr := NewLineReader(strings.NewReader("a\r\nb\n\nc"))
+	for lr.Scan() {
+		endings = append(endings, lr.Ending())
+	}
+
+	if want := []string{"\r\n", "\n", "\n", ""}; !reflect.DeepEqual(endings, want) {
+		t.Errorf("Ending():\nwant %q\ngot  %q\n", want, endings)
+	}
+}
+
+// TestEachLine() checks the line numbers and that an error from the callback stops
+// the reading.
+func TestEachLine(t *testing.T) {
+	var numbers []int
+	stop := errors.New("stop")
+
+	err := EachLine(strings.NewReader("a\n\nb\nc\n"), func(lineNumber int, line string) error {
+		numbers = append(numbers, lineNumber)
+		if line == "b" {
+			return stop
+		}
+
+		return nil
+	})
+
+	if err != stop || !reflect.DeepEqual(numbers, []int{1, 2, 3}) {
+		t.Errorf("EachLine():\nwant lines [1 2 3] and %v\ngot  %v and %v\n", stop, numbers, err)
+	}
+}
+
+// TestReadHelpers() reads a file as a string, as bytes and as blocks.
+func TestReadHelpers(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "input.txt")
+	contents := "1\n2\n\n\n3\r\n\n4\n5\n\n"
+	if err := os.WriteFile(filename, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := ReadString(filename); err != nil || s != contents {
+		t.Errorf("ReadString():\nwant %q\ngot  %q (%v)\n", contents, s, err)
+	}
+
+	if b, err := ReadBytes(filename); err != nil || string(b) != contents {
+		t.Errorf("ReadBytes():\nwant %q\ngot  %q (%v)\n", contents, b, err)
+	}
+
+	want := [][]string{{"1", "2"}, {"3"}, {"4", "5"}}
+	if blocks, err := ReadBlocks(filename); err != nil || !reflect.DeepEqual(blocks, want) {
+		t.Errorf("ReadBlocks():\nwant %q\ngot  %q (%v)\n", want, blocks, err)
+	}
+}
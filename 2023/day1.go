@@ -6,29 +6,51 @@ package main
 
 import (
 	"fileprocessing"
+	"fmt"
 	"log"
 	"strconv"
 	"strings"
+	"unicode"
+
+	"sciencerocketry.com/explain"
 )
 
+// day1Format is the format of the input - a line of text for each calibration value.
+// Any letters, in any language or case, get as far as the solver, which decides what
+// counts as a digit, so only whitespace and control characters are rejected.
+var day1Format = &inputFormat{
+	sections: []sectionFormat{{
+		lines: &lineFormat{
+			pattern: fileprocessing.MustPattern("{value}"),
+			check: func(m *fileprocessing.Match) error {
+				value, _ := m.Field("value")
+				for i, c := range value.Text {
+					if unicode.IsSpace(c) || unicode.IsControl(c) {
+						return fmt.Errorf("column %d: unexpected %q", value.Column+i, c)
+					}
+				}
+
+				return nil
+			},
+		},
+	}},
+}
+
 // default value to return when parsing a line goes bad
 const defaultRune rune = 'X'
 
 // day1() wraps the two parts of the solution and returns a string (output) with the
 // results of the two solves.
-func day1(name string, inputFile string) string {
-	fileContents, _, err := fileprocessing.ReadFile(inputFile)
-	if err != nil {
-		log.Fatal(err)
-	}
-
+func day1(name string, fileContents []string, ex *explain.Explainer) string {
 	var output strings.Builder
 
 	// part 1
 
 	sumOfCalibrationValues := 0
-	for _, line := range fileContents {
-		sumOfCalibrationValues += getCalibrationValue(findFirstDigit(line), findLastDigit(line))
+	for i, line := range fileContents {
+		value, matches := lineCalibrationValue(numeralMatcher, line)
+		explainCalibrationValue(ex, 1, i, matches, value)
+		sumOfCalibrationValues += value
 	}
 
 	strSumOfCalibrationValues := strconv.Itoa(sumOfCalibrationValues)
@@ -42,8 +64,10 @@ func day1(name string, inputFile string) string {
 	// part 2
 
 	sumOfCalibrationValues = 0
-	for _, line := range fileContents {
-		sumOfCalibrationValues += getCalibrationValue(findFirstDigitWithSubstitution(line), findLastDigitWithSubstitution(line))
+	for i, line := range fileContents {
+		value, matches := lineCalibrationValue(spelledOutMatcher, line)
+		explainCalibrationValue(ex, 2, i, matches, value)
+		sumOfCalibrationValues += value
 	}
 
 	strSumOfCalibrationValues = strconv.Itoa(sumOfCalibrationValues)
@@ -74,150 +98,85 @@ func getCalibrationValue(first rune, last rune) int {
 	return calibrationVal
 }
 
-// part 1 looks just for digits. The functions below start from the beginning and end,
-// respectively looking for a digit from which to build the calibration value
+// lineCalibrationValue() finds every digit in the line in a single pass of the matcher
+// and returns the calibration value made from the first and last of them, along with
+// the digits that were found
+func lineCalibrationValue(matcher *digitMatcher, line string) (int, []digitMatch) {
+	matches := matcher.findAll(line)
 
-// findFirstDigit(line string) returns the first digit encountered when parsing the input 'line'
-// from left to right
-func findFirstDigit(line string) rune {
-	runes := []rune(line)
-	for i := 0; i < len(runes); i++ {
-		if isDigit(runes[i]) {
-			return runes[i]
+	return getCalibrationValue(digitRune(firstMatch(matches)), digitRune(lastMatch(matches))), matches
+}
+
+// explainCalibrationValue() explains the calibration value of the line at index 'i' with
+// the digits found in it, e.g. 'first two=2 at 0-3, last nine=9 at 4-8'
+func explainCalibrationValue(ex *explain.Explainer, part int, i int, matches []digitMatch, value int) {
+	if !ex.Enabled() {
+		return
+	}
+
+	if len(matches) == 0 {
+		if err := ex.Explain(explain.Explanation{Part: part, Item: "line " + strconv.Itoa(i+1), Value: value, Reason: "no digits"}); err != nil {
+			log.Fatal(err)
 		}
+
+		return
+	}
+
+	first, _ := firstMatch(matches)
+	last, _ := lastMatch(matches)
+	if err := ex.Explain(explain.Explanation{Part: part, Item: "line " + strconv.Itoa(i+1), Value: value, Reason: fmt.Sprintf("first %v, last %v, found %s", first, last, describeMatches(matches))}); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// numeralMatcher finds the digits for part 1 and spelledOutMatcher finds them whether
+// they are numerals or spelled out in English for part 2. A matcher can be built from
+// other vocabularies, such as spelledOut["french"] or zero, in the same way.
+var numeralMatcher = mustDigitMatcher(numerals)
+var spelledOutMatcher = mustDigitMatcher(numerals, spelledOut["english"])
+
+// digitRune() converts a match into the rune for its digit, or defaultRune if there
+// wasn't a match
+func digitRune(match digitMatch, ok bool) rune {
+	if !ok {
+		return defaultRune
 	}
 
-	return defaultRune
+	return rune('0' + match.digit)
+}
+
+// part 1 looks just for digits. The functions below find the first and last digit in a
+// line from which to build the calibration value
+
+// findFirstDigit(line string) returns the first digit encountered when parsing the input 'line'
+// from left to right
+func findFirstDigit(line string) rune {
+	return digitRune(numeralMatcher.first(line))
 }
 
 // findLastDigit(line string) returns the first digit encountered when parsing the input 'line'
 // from right to left
 func findLastDigit(line string) rune {
-	runes := []rune(line)
-	for i := len(runes) - 1; i >= 0; i-- {
-		if isDigit(runes[i]) {
-			return runes[i]
-		}
-	}
-
-	return defaultRune
+	return digitRune(numeralMatcher.last(line))
 }
 
 // part 2 looks just for either the digit or a numeral that is spelled out. The functions below
-// start from the beginning and end, respectively looking for a digit, whether numeric or spelled
-// out, from which to build the calibration value
+// find the first and last digit, whether numeric or spelled out, from which to build the
+// calibration value
 
 // I made the mistake of navigating each line and replacing the spelled out version with a number
 // and then finding the first and last numeric digit. If you have a line like '1twone', starting
-// from the beginning gives you '12ne'. But if you start from the end, you get '1tw1'. So you
-// have to start from the end when finding the second digit in a calibration value.
+// from the beginning gives you '12ne'. But if you start from the end, you get '1tw1'. The matcher
+// finds overlapping words, so 'twone' is both a 'two' and a 'one' and both ends work.
 
 // findFirstDigitWithSubstitution(line string) returns the first digit encountered when parsing
 // the input 'line' from left to right whether it is spelled out or provided numerically
 func findFirstDigitWithSubstitution(line string) rune {
-	digits := [...]string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
-
-	runes := []rune(line)
-	for i := 0; i < len(runes); i++ {
-		if isDigit(runes[i]) {
-			return runes[i]
-		}
-
-		// this might shave a few cycles if the string 'line[i:]' is shorter than three. Any
-		// check of the spelled-out digits would be impossible in that circumstance.
-		//   - you could get cute and only check the digits when 'len(line[i:]) < len(digit)'
-		//     (or similar logic) but the performance benefits would depend on the input
-
-		for _, digit := range digits {
-			found := strings.Index(line[i:], digit)
-			if found == 0 {
-				return convertStringDigitToRune(digit)
-			}
-		}
-	}
-
-	return defaultRune
+	return digitRune(spelledOutMatcher.first(line))
 }
 
 // findLastDigitWithSubstitution(line string) returns the first digit encountered when parsing
 // the input 'line' from right to left whether it is spelled out or provided numerically
 func findLastDigitWithSubstitution(line string) rune {
-	digits := [...]string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
-
-	runes := []rune(line)
-	for i := len(runes) - 1; i >= 0; i-- {
-		if isDigit(runes[i]) {
-			return runes[i]
-		}
-
-		// this might shave a few cycles if the string 'line[i:]' is shorter than three. Any
-		// check of the spelled-out digits would be impossible in that circumstance.
-		//   - you could get cute and only check the digits when 'len(line[i:]) < len(digit)'
-		//     (or similar logic) but the performance benefits would depend on the input
-
-		for _, digit := range digits {
-			found := strings.Index(line[i:], digit)
-			if found == 0 {
-				return convertStringDigitToRune(digit)
-			}
-		}
-	}
-
-	return defaultRune
-}
-
-// convertStringDigitToRune(digit string) converts a string spelled as a number to its rune
-// (character) equivalent
-func convertStringDigitToRune(digit string) rune {
-	switch digit {
-	case "one":
-		return '1'
-	case "two":
-		return '2'
-	case "three":
-		return '3'
-	case "four":
-		return '4'
-	case "five":
-		return '5'
-	case "six":
-		return '6'
-	case "seven":
-		return '7'
-	case "eight":
-		return '8'
-	case "nine":
-		return '9'
-	default:
-		log.Fatal("Could not convert [", digit, "] to its numerical value")
-	}
-
-	return defaultRune
-}
-
-// isDigit(c rune) checks whether the rune value provided is a digit or not
-func isDigit(c rune) bool {
-	switch c {
-	case '1':
-		fallthrough
-	case '2':
-		fallthrough
-	case '3':
-		fallthrough
-	case '4':
-		fallthrough
-	case '5':
-		fallthrough
-	case '6':
-		fallthrough
-	case '7':
-		fallthrough
-	case '8':
-		fallthrough
-	case '9':
-		return true
-
-	}
-
-	return false
+	return digitRune(spelledOutMatcher.last(line))
 }
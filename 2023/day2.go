@@ -8,95 +8,247 @@ import (
 	"fileprocessing"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
+
+	"sciencerocketry.com/explain"
 )
 
-type set struct {
-	red   int
-	green int
-	blue  int
+// cubesPattern matches the count of a single colour in a set, e.g. '3 blue'
+var cubesPattern = fileprocessing.MustPattern("{count:int} {colour}")
+
+// day2Format is the format of the input - a line for each game with sets of cubes
+// separated by ';' and the counts of each colour in a set separated by ','
+var day2Format = &inputFormat{
+	sections: []sectionFormat{{
+		lines: &lineFormat{
+			pattern: gamePattern,
+			check:   checkItems("sets", ";,", cubesPattern),
+		},
+	}},
 }
 
+// set is the number of cubes of each colour pulled from the bag at once. Any colour
+// name can be used and a colour that wasn't pulled has a count of 0.
+type set map[string]int
+
 type game struct {
-	sets []*set
+	sets []set
 	id   int
 }
 
+// bag is the number of cubes of each colour in the bag. A colour that isn't in the bag
+// has no cubes.
+type bag map[string]int
+
+// day2Bag is the bag the Elf asks about in part 1. It can be changed with the '-bag' flag.
+var day2Bag = bag{"red": 12, "green": 13, "blue": 14}
+
+// bag.colours() returns the colours in the bag in alphabetical order
+func (b bag) colours() []string {
+	var colours []string
+	for colour := range b {
+		colours = append(colours, colour)
+	}
+
+	sort.Strings(colours)
+
+	return colours
+}
+
+// bag.String() formats a bag the way the '-bag' flag expects it, e.g. 'blue=14,green=13,red=12'
+func (b bag) String() string {
+	var counts []string
+	for _, colour := range b.colours() {
+		counts = append(counts, colour+"="+strconv.Itoa(b[colour]))
+	}
+
+	return strings.Join(counts, ",")
+}
+
+// bag.Set() parses a bag from a list like 'red=12,green=13,blue=14', replacing what was in
+// the bag. It lets a bag be used as a flag.
+func (b bag) Set(value string) error {
+	counts := bag{}
+	for _, count := range strings.Split(value, ",") {
+		colour, number, found := strings.Cut(count, "=")
+		colour = strings.TrimSpace(colour)
+		if !found || len(colour) == 0 {
+			return fmt.Errorf("'%s' isn't like 'red=12'", count)
+		}
+
+		n, err := strconv.Atoi(strings.TrimSpace(number))
+		if err != nil || n < 0 {
+			return fmt.Errorf("'%s' doesn't have a valid number of cubes", count)
+		}
+
+		counts[colour] = n
+	}
+
+	for colour := range b {
+		delete(b, colour)
+	}
+
+	for colour, n := range counts {
+		b[colour] = n
+	}
+
+	return nil
+}
+
+// bag.power() multiplies together the number of cubes of each of the specified colours
+func (b bag) power(colours []string) int {
+	power := 1
+	for _, colour := range colours {
+		power *= b[colour]
+	}
+
+	return power
+}
+
 // game.print() prints a game
 func (g *game) print() {
 	fmt.Printf("game.id: %d\n", g.id)
 	for i, s := range g.sets {
-		fmt.Printf("Set %d - Red: %d, Green: %d, Blue: %d\n", i+1, s.red, s.green, s.blue)
+		fmt.Printf("Set %d - %s\n", i+1, bag(s))
 	}
 }
 
+// gamePattern matches a line of the input, e.g. 'Game 1: 3 blue, 4 red; 1 red, 2 green'
+var gamePattern = fileprocessing.MustPattern("Game {id:int}: {sets}")
+
 // game.new() parses an input string into a game structure
 func (g *game) new(input string) {
-	index := strings.Index(input, ": ")
-	if index < 0 {
-		log.Fatal("When parsing '" + input + "' the index for a ':' value could not be found")
+	var allSets string
+	if err := gamePattern.Scan(input, &g.id, &allSets); err != nil {
+		log.Fatalf("When parsing '%s': %v", input, err)
 	}
 
-	id, err := strconv.Atoi(input[5:index])
-	if err != nil {
-		log.Fatal(err)
+	for _, thisSet := range strings.Split(allSets, ";") {
+		s := set{}
+		for _, value := range strings.Split(thisSet, ",") {
+			var count int
+			var colour string
+			if err := cubesPattern.Scan(value, &count, &colour); err != nil {
+				log.Fatalf("When parsing '%s' in game %d: %v", strings.TrimSpace(value), g.id, err)
+			}
+
+			s[colour] += count
+		}
+
+		g.sets = append(g.sets, s)
 	}
+}
+
+// violation is a set that had more cubes of a colour than the bag holds
+type violation struct {
+	set    int // the index of the set in the game
+	colour string
+	count  int
+	limit  int
+}
 
-	g.id = id
+// game.violation() returns the first set that couldn't have come from the bag. If every
+// set could have, ok is false.
+func (g *game) violation(b bag) (v violation, ok bool) {
+	for i, s := range g.sets {
+		colours := make([]string, 0, len(s))
+		for colour := range s {
+			colours = append(colours, colour)
+		}
 
-	sets := strings.Split(input[index+1:], ";")
+		sort.Strings(colours)
 
-	numSets := len(sets)
-	if numSets > 0 {
-		g.sets = make([]*set, numSets)
+		for _, colour := range colours {
+			if s[colour] > b[colour] {
+				return violation{set: i, colour: colour, count: s[colour], limit: b[colour]}, true
+			}
+		}
 	}
 
-	for i, thisSet := range sets {
-		// clean up the input string by removing leading and trailing spaces
-		thisSet = strings.Trim(thisSet, " ")
+	return v, false
+}
 
-		// initialize the set
-		g.sets[i] = new(set)
-		g.sets[i].blue = 0
-		g.sets[i].green = 0
-		g.sets[i].red = 0
+// game.possible() reports whether every set in the game could have come from the bag
+func (g *game) possible(b bag) bool {
+	_, broken := g.violation(b)
+	return !broken
+}
 
-		values := strings.Split(thisSet, ", ")
-		for _, value := range values {
-			redVal := getColorValue(value, "red")
-			if redVal > 0 {
-				g.sets[i].red = redVal
-			}
+// game.minimumBag() returns the fewest cubes of each colour the bag could have held
+// for the game to be possible
+func (g *game) minimumBag() bag {
+	minimum := bag{}
+	for _, s := range g.sets {
+		for colour, count := range s {
+			minimum[colour] = max(minimum[colour], count)
+		}
+	}
 
-			blueVal := getColorValue(value, "blue")
-			if blueVal > 0 {
-				g.sets[i].blue = blueVal
-			}
+	return minimum
+}
+
+// colourStats summarises the cubes of one colour pulled across all of the games
+type colourStats struct {
+	colour string
+	sets   int // the number of sets the colour was pulled in
+	total  int
+	most   int // the most pulled in a single set
+}
 
-			greenVal := getColorValue(value, "green")
-			if greenVal > 0 {
-				g.sets[i].green = greenVal
+// colourStats.mean() is the average number pulled in the sets the colour was pulled in
+func (c colourStats) mean() float64 {
+	if c.sets == 0 {
+		return 0
+	}
+
+	return float64(c.total) / float64(c.sets)
+}
+
+// gameStats() returns the statistics for each colour pulled in the games, in
+// alphabetical order of colour
+func gameStats(games []*game) []colourStats {
+	byColour := map[string]*colourStats{}
+	for _, g := range games {
+		for _, s := range g.sets {
+			for colour, count := range s {
+				stats, found := byColour[colour]
+				if !found {
+					stats = &colourStats{colour: colour}
+					byColour[colour] = stats
+				}
+
+				stats.sets++
+				stats.total += count
+				stats.most = max(stats.most, count)
 			}
 		}
 	}
+
+	var stats []colourStats
+	for _, s := range byColour {
+		stats = append(stats, *s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].colour < stats[j].colour
+	})
+
+	return stats
 }
 
 // day2() wraps the two parts of the solution and returns a string (output) with the
 // results of the two solves.
-func day2(name string, inputFile string) string {
-	fileContents, _, err := fileprocessing.ReadFile(inputFile)
-	if err != nil {
-		log.Fatal(err)
-	}
-
+func day2(name string, fileContents []string, ex *explain.Explainer) string {
 	games := make([]*game, len(fileContents))
 	for i, input := range fileContents {
+		games[i] = new(game)
 		games[i].new(input)
 	}
 
-	sumOfPossibleGames := day2part1(games)
-	sumOfPowers := day2part2(games)
+	sumOfPossibleGames := day2part1(games, ex)
+	sumOfPowers := day2part2(games, ex)
 
 	var output strings.Builder
 	output.WriteString("part 1 - sum of possible games: " + strconv.Itoa(sumOfPossibleGames))
@@ -104,85 +256,58 @@ func day2(name string, inputFile string) string {
 	output.WriteString("part 2 - sum of powers: " + strconv.Itoa(sumOfPowers))
 	output.WriteString("\n")
 
+	output.WriteString("bag: " + day2Bag.String() + "\n")
+	for _, stats := range gameStats(games) {
+		output.WriteString(fmt.Sprintf("  %s - pulled in %d sets, %d in total, at most %d, %.2f on average\n", stats.colour, stats.sets, stats.total, stats.most, stats.mean()))
+	}
+
 	return output.String()
 }
 
 // day2part1() handles the first part of the day's challenges by determining the sum of the
 // game numbers of the games that are possible based on the Elf's question on what
-// games are possible if the bag only contains 12 red cubes, 13 green cubes, and 14 blue cubes
-func day2part1(games []*game) int {
-	redLimit := 12
-	greenLimit := 13
-	blueLimit := 14
-
+// games are possible if the bag only contains 12 red cubes, 13 green cubes, and 14 blue
+// cubes (or whatever is in day2Bag)
+func day2part1(games []*game, ex *explain.Explainer) int {
 	sum := 0
 
 	for _, g := range games {
-		possible := true
-		for _, s := range g.sets {
-			if s.red > redLimit ||
-				s.green > greenLimit ||
-				s.blue > blueLimit {
-				// a set was pulled that has a color that exceeds the limits, making this game
-				// not possible
-				possible = false
-			}
-		}
-
-		if possible {
+		v, broken := g.violation(day2Bag)
+		if !broken {
 			sum += g.id
+			if err := ex.Explain(explain.Explanation{Part: 1, Item: "game " + strconv.Itoa(g.id), Value: g.id, Reason: "possible"}); err != nil {
+				log.Fatal(err)
+			}
+		} else {
+			if err := ex.Explain(explain.Explanation{Part: 1, Item: "game " + strconv.Itoa(g.id), Reason: fmt.Sprintf("impossible, set %d has %d %s but the bag holds %d", v.set+1, v.count, v.colour, v.limit)}); err != nil {
+				log.Fatal(err)
+			}
 		}
 	}
 
 	return sum
 }
 
-// day2part2() handles the first part of the day's challenges by determining the sum of the
-// game numbers of the games that are possible based on the Elf's question on what
-// games are possible if the bag only contains 12 red cubes, 13 green cubes, and 14 blue cubes
-func day2part2(games []*game) int {
+// day2part2() handles the second part of the day's challenges by finding the fewest cubes
+// of each colour that make each game possible and summing the "power" of those bags (the
+// product of the number of cubes of each colour seen in any of the games, so a game
+// without one of the colours has no power). The bag in day2Bag only matters to part 1.
+func day2part2(games []*game, ex *explain.Explainer) int {
 	sum := 0
 
-	for _, g := range games {
-		redValue := 0
-		greenValue := 0
-		blueValue := 0
-
-		for _, s := range g.sets {
-			if s.red > redValue {
-				redValue = s.red
-			}
-
-			if s.blue > blueValue {
-				blueValue = s.blue
-			}
-
-			if s.green > greenValue {
-				greenValue = s.green
-			}
-		}
-
-		sum += redValue * blueValue * greenValue
+	var colours []string
+	for _, stats := range gameStats(games) {
+		colours = append(colours, stats.colour)
 	}
 
-	return sum
-}
-
-// getColorValue() takes a given color and, if it exists in the input string 'value', it
-// returns the numeric value of the number preceding the color
-//
-//	e.g. 'value' of '23 red' will return the integer 23 if the 'color' parameter is 'red',
-//	     but will return 0 if the 'color' parameter is 'green'
-func getColorValue(value string, color string) int {
-	colorIndex := strings.Index(value, color)
-	if colorIndex > 0 {
-		val, err := strconv.Atoi(value[:colorIndex-1])
-		if err != nil {
+	for _, g := range games {
+		minimum := g.minimumBag()
+		power := minimum.power(colours)
+		if err := ex.Explain(explain.Explanation{Part: 2, Item: "game " + strconv.Itoa(g.id), Value: power, Reason: fmt.Sprintf("the fewest cubes are %s", minimum)}); err != nil {
 			log.Fatal(err)
 		}
-
-		return val
+		sum += power
 	}
 
-	return -1
+	return sum
 }
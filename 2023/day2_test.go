@@ -22,7 +22,7 @@ func TestPossibleGames(t *testing.T) {
 	}
 
 	expectedSum := 8
-	actualSum := day2part1(games)
+	actualSum := day2part1(games, nil)
 	if expectedSum != actualSum {
 		t.Errorf("expected: " + strconv.Itoa(expectedSum) + ", actual: " + strconv.Itoa(actualSum))
 	}
@@ -44,7 +44,7 @@ func TestPowerSum(t *testing.T) {
 	}
 
 	expectedPowerSum := 2286
-	actualPowerSum := day2part2(games)
+	actualPowerSum := day2part2(games, nil)
 	if expectedPowerSum != actualPowerSum {
 		t.Errorf("expected: " + strconv.Itoa(expectedPowerSum) + ", actual: " + strconv.Itoa(actualPowerSum))
 	}
@@ -60,10 +60,10 @@ func TestNewGame(t *testing.T) {
 
 	results := make([]*game, 0)
 	results = append(results, &game{
-		sets: []*set{
-			{red: 4, green: 0, blue: 3},
-			{red: 1, green: 2, blue: 6},
-			{red: 0, green: 2, blue: 0},
+		sets: []set{
+			{"red": 4, "blue": 3},
+			{"red": 1, "green": 2, "blue": 6},
+			{"green": 2},
 		},
 		id: 1},
 	)
@@ -98,18 +98,124 @@ func validateGame(g1 *game, g2 *game) error {
 	return nil
 }
 
-func validateSet(s1 *set, s2 *set) error {
-	if s1.blue != s2.blue {
-		return errors.New("blue = " + strconv.Itoa(s1.blue) + ", expected: " + strconv.Itoa(s2.blue))
+func validateSet(s1 set, s2 set) error {
+	for _, colour := range []string{"red", "green", "blue", "purple"} {
+		if s1[colour] != s2[colour] {
+			return errors.New(colour + " = " + strconv.Itoa(s1[colour]) + ", expected: " + strconv.Itoa(s2[colour]))
+		}
 	}
 
-	if s1.red != s2.red {
-		return errors.New("red = " + strconv.Itoa(s1.red) + ", expected: " + strconv.Itoa(s2.red))
+	return nil
+}
+
+// sampleGames() parses the example games from the puzzle
+func sampleGames() []*game {
+	inputs := []string{
+		"Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green",
+		"Game 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue",
+		"Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red",
+		"Game 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red",
+		"Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green",
 	}
 
-	if s1.green != s2.green {
-		return errors.New("green = " + strconv.Itoa(s1.green) + ", expected: " + strconv.Itoa(s2.green))
+	games := make([]*game, 0)
+	for _, input := range inputs {
+		g := new(game)
+		g.new(input)
+		games = append(games, g)
 	}
 
-	return nil
+	return games
+}
+
+func TestViolation(t *testing.T) {
+	var tests = []struct {
+		game     int
+		possible bool
+		result   violation
+	}{
+		{1, true, violation{}},
+		{3, false, violation{set: 0, colour: "red", count: 20, limit: 12}},
+		{4, false, violation{set: 2, colour: "blue", count: 15, limit: 14}},
+	}
+
+	games := sampleGames()
+	for _, test := range tests {
+		v, broken := games[test.game-1].violation(day2Bag)
+		if broken == test.possible || v != test.result {
+			t.Errorf("violation(%d):\nwant %+v (possible: %t)\ngot %+v\n", test.game, test.result, test.possible, v)
+		}
+	}
+}
+
+func TestMinimumBag(t *testing.T) {
+	var tests = []struct {
+		game   int
+		result string
+		power  int
+	}{
+		{1, "blue=6,green=2,red=4", 48},
+		{3, "blue=6,green=13,red=20", 1560},
+		{5, "blue=2,green=3,red=6", 36},
+	}
+
+	games := sampleGames()
+	for _, test := range tests {
+		minimum := games[test.game-1].minimumBag()
+		if minimum.String() != test.result || minimum.power(minimum.colours()) != test.power {
+			t.Errorf("minimumBag(%d):\nwant %s (power %d)\ngot %s (power %d)\n", test.game, test.result, test.power, minimum, minimum.power(minimum.colours()))
+		}
+	}
+}
+
+// TestPowerMissingColour() checks a game without one of the colours the other games
+// have has no power
+func TestPowerMissingColour(t *testing.T) {
+	var games []*game
+	for _, line := range []string{"Game 1: 3 red, 2 green; 1 blue", "Game 2: 4 red; 5 blue"} {
+		g := new(game)
+		g.new(line)
+		games = append(games, g)
+	}
+
+	if sum := day2part2(games, nil); sum != 6 {
+		t.Errorf("day2part2() with game 2 missing green:\nwant 6\ngot %v\n", sum)
+	}
+}
+
+func TestAnyColours(t *testing.T) {
+	g := new(game)
+	g.new("Game 7: 2 purple, 1 red; 5 purple;  3 teal")
+
+	b := bag{}
+	if err := b.Set("purple=4, red=1, teal=3"); err != nil {
+		t.Fatal(err)
+	}
+
+	v, broken := g.violation(b)
+	if !broken || v.set != 1 || v.colour != "purple" {
+		t.Errorf("violation():\nwant set 1 broken by purple\ngot %+v\n", v)
+	}
+
+	if minimum := g.minimumBag(); minimum.String() != "purple=5,red=1,teal=3" {
+		t.Errorf("minimumBag():\nwant purple=5,red=1,teal=3\ngot %s\n", minimum)
+	}
+
+	for _, value := range []string{"red", "red=x", "=3", "red=-1"} {
+		if err := b.Set(value); err == nil {
+			t.Errorf("Set(%s): expected an error\n", value)
+		}
+	}
+}
+
+func TestGameStats(t *testing.T) {
+	stats := gameStats(sampleGames())
+	if len(stats) != 3 {
+		t.Fatalf("gameStats():\nwant 3 colours\ngot %d\n", len(stats))
+	}
+
+	red := stats[2]
+	if red.colour != "red" || red.sets != 11 || red.total != 61 || red.most != 20 {
+		t.Errorf("gameStats() red:\nwant 11 sets, 61 in total, at most 20\ngot %+v\n", red)
+	}
 }
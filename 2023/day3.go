@@ -11,43 +11,72 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"sciencerocketry.com/explain"
 )
 
+// day3Format is the format of the input - a grid of digits, '.' and symbols
+var day3Format = &inputFormat{
+	sections: []sectionFormat{{
+		lines: &lineFormat{
+			// the space after the field makes the row a single word, so a row with
+			// whitespace in it doesn't match
+			pattern: fileprocessing.MustPattern("{row} "),
+		},
+		sameLength: true,
+	}},
+}
+
 // day3() has the Elf and I reaching a gondola lift station which will take us up to
 // the water source, but the gondolas aren't moving and we need to use the engine
 // schematic to fix it.
-func day3(name string, inputFile string) string {
-	fileContents, _, err := fileprocessing.ReadFile(inputFile)
-	if err != nil {
-		log.Fatal(err)
-	}
-
+func day3(name string, fileContents []string, ex *explain.Explainer) string {
 	e := new(engineSchematic)
 	e.new(fileContents)
 
-	sumOfPartNumbers := strconv.Itoa(day3part1(e))
+	sumOfPartNumbers := strconv.Itoa(day3part1(e, ex))
 
 	var output strings.Builder
 	output.WriteString("Part 1:\n")
 	output.WriteString("The sum of all valid part numbers is: " + sumOfPartNumbers)
 	output.WriteString("\n")
 
-	sumOfGears := strconv.Itoa(day3part2(e))
+	sumOfGears := strconv.Itoa(day3part2(e, ex))
 
 	output.WriteString("Part 2:\n")
 	output.WriteString("The sum of all gear ratios is: " + sumOfGears)
 
+	// a '*' next to more than two numbers isn't a gear, but it's probably worth knowing about
+	for _, a := range e.anomalies(symbolIs(gear_symbol), 2) {
+		output.WriteString("\n" + a.String())
+	}
+
 	return output.String()
 }
 
 // day3part1() calculates the sum of partNumbers by looking for partNumbers that
 // have an adjacent symbol (which confirms it is a part number)
-func day3part1(e *engineSchematic) int {
+func day3part1(e *engineSchematic, ex *explain.Explainer) int {
 	sum := 0
 
-	for _, partNumber := range e.partNumbers {
-		if e.partNumberHasAdjacentSymbol(partNumber) {
-			sum += partNumber.value
+	counted := make(map[*partNumber]bool)
+	for _, partNumber := range e.partsNextTo(anySymbol) {
+		counted[partNumber] = true
+		sum += partNumber.value
+	}
+
+	if ex.Enabled() {
+		for _, partNumber := range e.partNumbers {
+			if counted[partNumber] {
+				if err := ex.Explain(explain.Explanation{Part: 1, Item: partNumber.String(), Value: partNumber.value, Reason: "next to a symbol"}); err != nil {
+					log.Fatal(err)
+				}
+			} else {
+				if err := ex.Explain(explain.Explanation{Part: 1, Item: partNumber.String(), Reason: "not next to a symbol"}); err != nil {
+					log.Fatal(err)
+				}
+			}
 		}
 	}
 
@@ -56,51 +85,32 @@ func day3part1(e *engineSchematic) int {
 
 const gear_symbol = '*'
 
-func day3part2(e *engineSchematic) int {
+// day3part2() sums the gear ratios - the product of the two part numbers next to each
+// '*' that has exactly two. A '*' next to more than two is reported by anomalies().
+func day3part2(e *engineSchematic, ex *explain.Explainer) int {
 	sum := 0
 
-	// get each '*'
-	for row, r := range e.schematic {
-		for col, _ := range r {
-			if e.schematic[row][col] == gear_symbol {
-				firstGear := 0
-				secondGear := 0
-
-				// 	parse the partNumbers and find out if there are two gears that are adjacent
-				for _, partNumber := range e.partNumbers {
-					if row < partNumber.row-1 {
-						continue
-					}
-
-					if row > partNumber.row+1 {
-						continue
-					}
-
-					if partNumber.isAdjacentTo(row, col) {
-						if firstGear == 0 {
-							firstGear = partNumber.value
-						} else {
-							if secondGear == 0 {
-								secondGear = partNumber.value
-							} else {
-								log.Fatal("found more than two numbers adjacent to the gear at " + strconv.Itoa(row) + ", " + strconv.Itoa(col))
-							}
-						}
-					}
-				}
-
-				// 	if so, multiply and add to the sum (if no, the product will be 0)
-				sum += firstGear * secondGear
-			}
+	for _, g := range e.gears(symbolIs(gear_symbol), 2) {
+		if err := ex.Explain(explain.Explanation{Part: 2, Item: g.symbol.String(), Value: g.ratio(), Reason: fmt.Sprintf("a gear next to %s and %s", g.parts[0], g.parts[1])}); err != nil {
+			log.Fatal(err)
 		}
+		sum += g.ratio()
 	}
 
 	return sum
 }
 
+// numberRegexp finds the numbers in a row of the schematic
+var numberRegexp = regexp.MustCompile(`\d+`)
+
 type engineSchematic struct {
 	schematic   [][]rune
 	partNumbers []*partNumber
+	symbols     []symbol
+
+	// covering is the spatial index of the schematic - for each cell, the index in
+	// partNumbers of the number covering it, or -1
+	covering [][]int
 }
 
 type partNumber struct {
@@ -108,17 +118,56 @@ type partNumber struct {
 	row, start, end int
 }
 
+// partNumber.String() describes where a number is, with the line and column starting
+// from 1, e.g. '467 at line 1, column 1'
+func (p *partNumber) String() string {
+	return fmt.Sprintf("%d at line %d, column %d", p.value, p.row+1, p.start+1)
+}
+
+// symbol is a character in the schematic that isn't a digit or a '.'
+type symbol struct {
+	r        rune
+	row, col int
+}
+
+// symbol.String() describes where a symbol is, with the line and column starting
+// from 1, e.g. "'*' at line 2, column 4"
+func (s symbol) String() string {
+	return fmt.Sprintf("'%c' at line %d, column %d", s.r, s.row+1, s.col+1)
+}
+
+// symbolClass picks out the symbols a query is interested in
+type symbolClass func(r rune) bool
+
+// anySymbol() is the class of every symbol
+func anySymbol(r rune) bool {
+	return !runeIsNumberOrDot(r)
+}
+
+// symbolIs() returns the class of a single symbol, such as '*'
+func symbolIs(s rune) symbolClass {
+	return func(r rune) bool {
+		return r == s
+	}
+}
+
+// new() parses the rows of the schematic, finding the numbers and the symbols and
+// indexing the cells each number covers. Columns count characters rather than bytes.
 func (e *engineSchematic) new(input []string) {
 	for row, s := range input {
-		e.schematic = append(e.schematic, []rune(s))
+		runes := []rune(s)
+		e.schematic = append(e.schematic, runes)
+
+		covering := make([]int, len(runes))
+		for col := range covering {
+			covering[col] = -1
+		}
 
-		re := regexp.MustCompile(`\d+`)
-		matches := re.FindAllStringIndex(s, -1)
-		for _, match := range matches {
+		for _, match := range numberRegexp.FindAllStringIndex(s, -1) {
 			p := new(partNumber)
-			p.start = match[0]
-			p.end = match[1]
-			value, err := strconv.Atoi(s[p.start:p.end])
+			p.start = utf8.RuneCountInString(s[:match[0]])
+			p.end = p.start + (match[1] - match[0])
+			value, err := strconv.Atoi(s[match[0]:match[1]])
 			if err != nil {
 				log.Fatal(err)
 			}
@@ -126,97 +175,156 @@ func (e *engineSchematic) new(input []string) {
 			p.value = value
 			p.row = row
 
+			for col := p.start; col < p.end; col++ {
+				covering[col] = len(e.partNumbers)
+			}
+
 			e.partNumbers = append(e.partNumbers, p)
 		}
-	}
-}
-
-// print() prints a given engine schematic
-func (e *engineSchematic) print() {
-	fmt.Printf("engine schematic (input):")
-	fmt.Println()
 
-	for _, row := range e.schematic {
-		for _, col := range row {
-			fmt.Printf("%c", col)
+		for col, r := range runes {
+			if anySymbol(r) {
+				e.symbols = append(e.symbols, symbol{r: r, row: row, col: col})
+			}
 		}
-		fmt.Println()
+
+		e.covering = append(e.covering, covering)
 	}
 }
 
-// getDimensions() returns the number of rows and columns in a given engine schematic
-func (e *engineSchematic) getDimensions() (int, int) {
-	numRows := len(e.schematic)
-	numCols := 0
-	if numRows > 0 {
-		numCols = len(e.schematic[0])
+// partIndexAt() returns the index of the number covering a cell, or -1 if no number
+// covers it or the cell is off the schematic
+func (e *engineSchematic) partIndexAt(row int, col int) int {
+	if row < 0 || row >= len(e.covering) || col < 0 || col >= len(e.covering[row]) {
+		return -1
 	}
 
-	return numRows, numCols
+	return e.covering[row][col]
 }
 
-// partNumberHasAdjacentSymbol() inspects a partNumber to determine if, on the
-// engineering schematic it is contained, it has an adjacent symbol
-func (e *engineSchematic) partNumberHasAdjacentSymbol(p *partNumber) bool {
-	numRows, numCols := e.getDimensions()
-	startIndex := p.start
-	if startIndex > 0 {
-		// check the column before where the partNumber starts
-		startIndex = startIndex - 1
+// neighbours() returns the numbers next to a cell (including diagonally), each once, in
+// the order they appear in the schematic
+func (e *engineSchematic) neighbours(row int, col int) []*partNumber {
+	var indexes []int
+	for r := row - 1; r <= row+1; r++ {
+		for c := col - 1; c <= col+1; c++ {
+			i := e.partIndexAt(r, c)
+			if i < 0 || (r == row && c == col) {
+				continue
+			}
+
+			// a number covers neighbouring cells in a row, so it's only a repeat if it
+			// was the last one found
+			if n := len(indexes); n > 0 && indexes[n-1] == i {
+				continue
+			}
+
+			indexes = append(indexes, i)
+		}
 	}
 
-	endIndex := p.end
-	if endIndex == numCols {
-		// don't go past the last column
-		endIndex = endIndex - 1
+	parts := make([]*partNumber, len(indexes))
+	for i, index := range indexes {
+		parts[i] = e.partNumbers[index]
 	}
 
-	// check above
-	if p.row > 0 {
-		for i := startIndex; i <= endIndex; i++ {
-			if !runeIsNumberOrDot(e.schematic[p.row-1][i]) {
-				return true
+	return parts
+}
+
+// partsNextTo() returns every number next to a symbol of the class, each once, in the
+// order they appear in the schematic
+func (e *engineSchematic) partsNextTo(class symbolClass) []*partNumber {
+	next := make(map[*partNumber]bool)
+	for _, s := range e.symbols {
+		if class(s.r) {
+			for _, p := range e.neighbours(s.row, s.col) {
+				next[p] = true
 			}
 		}
 	}
 
-	// check current row
-	if startIndex < p.start {
-		// check left, if there is a left
-		if !runeIsNumberOrDot(e.schematic[p.row][startIndex]) {
-			return true
+	var parts []*partNumber
+	for _, p := range e.partNumbers {
+		if next[p] {
+			parts = append(parts, p)
 		}
 	}
 
-	if endIndex == p.end {
-		// check right, if there is a right
-		if !runeIsNumberOrDot(e.schematic[p.row][endIndex]) {
-			return true
-		}
+	return parts
+}
+
+// gear is a symbol and the numbers next to it
+type gear struct {
+	symbol symbol
+	parts  []*partNumber
+}
+
+// ratio() multiplies together the numbers next to the gear
+func (g gear) ratio() int {
+	ratio := 1
+	for _, p := range g.parts {
+		ratio *= p.value
 	}
 
-	// check below
-	if p.row < numRows-1 {
-		for i := startIndex; i <= endIndex; i++ {
-			if !runeIsNumberOrDot(e.schematic[p.row+1][i]) {
-				return true
+	return ratio
+}
+
+// gears() returns the symbols of the class with exactly 'n' numbers next to them
+func (e *engineSchematic) gears(class symbolClass, n int) []gear {
+	var gears []gear
+	for _, s := range e.symbols {
+		if class(s.r) {
+			if parts := e.neighbours(s.row, s.col); len(parts) == n {
+				gears = append(gears, gear{symbol: s, parts: parts})
 			}
 		}
 	}
 
-	return false
+	return gears
 }
 
-// isAdjacentTo() the (x,y) coordinate provided is adjacent to the location of
-// the partNumber in the grid
-func (p *partNumber) isAdjacentTo(row int, col int) bool {
-	if row >= p.row-1 && row <= p.row+1 {
-		if col >= p.start-1 && col <= p.end {
-			return true
+// anomaly is a symbol with more numbers next to it than expected
+type anomaly struct {
+	gear
+	most int
+}
+
+// anomaly.String() describes the anomaly with the line and column of the symbol,
+// starting from 1
+func (a anomaly) String() string {
+	var values []string
+	for _, p := range a.parts {
+		values = append(values, strconv.Itoa(p.value))
+	}
+
+	return fmt.Sprintf("the %s is next to %d numbers (%s), but expected at most %d", a.symbol, len(a.parts), strings.Join(values, ", "), a.most)
+}
+
+// anomalies() returns the symbols of the class with more than 'most' numbers next to them
+func (e *engineSchematic) anomalies(class symbolClass, most int) []anomaly {
+	var anomalies []anomaly
+	for _, s := range e.symbols {
+		if class(s.r) {
+			if parts := e.neighbours(s.row, s.col); len(parts) > most {
+				anomalies = append(anomalies, anomaly{gear: gear{symbol: s, parts: parts}, most: most})
+			}
 		}
 	}
 
-	return false
+	return anomalies
+}
+
+// print() prints a given engine schematic
+func (e *engineSchematic) print() {
+	fmt.Printf("engine schematic (input):")
+	fmt.Println()
+
+	for _, row := range e.schematic {
+		for _, col := range row {
+			fmt.Printf("%c", col)
+		}
+		fmt.Println()
+	}
 }
 
 // runeIsNumberOrDot() determines whether the specified rune is a digit or '.' value
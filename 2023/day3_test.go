@@ -24,7 +24,7 @@ func TestSumOfValidPartNumbers(t *testing.T) {
 
 	e := new(engineSchematic)
 	e.new(input)
-	actualSum := day3part1(e)
+	actualSum := day3part1(e, nil)
 	if expectedSum != actualSum {
 		t.Errorf("expected: " + strconv.Itoa(expectedSum) + ", actual: " + strconv.Itoa(actualSum))
 	}
@@ -48,8 +48,73 @@ func TestSumOfGearRatios(t *testing.T) {
 
 	e := new(engineSchematic)
 	e.new(input)
-	actualSum := day3part2(e)
+	actualSum := day3part2(e, nil)
 	if expectedSum != actualSum {
 		t.Errorf("expected: " + strconv.Itoa(expectedSum) + ", actual: " + strconv.Itoa(actualSum))
 	}
 }
+
+func TestSchematicQueries(t *testing.T) {
+	input := []string{
+		"467..114..",
+		"...*......",
+		"..35..633.",
+		"......#...",
+		"617*......",
+		".....+.58.",
+		"..592.....",
+		"......755.",
+		"...$.*....",
+		".664.598..",
+	}
+
+	e := new(engineSchematic)
+	e.new(input)
+
+	if parts := e.neighbours(1, 3); len(parts) != 2 || parts[0].value != 467 || parts[1].value != 35 {
+		t.Errorf("neighbours(1, 3):\nwant 467 and 35\ngot %v\n", parts)
+	}
+
+	if parts := e.partsNextTo(symbolIs('#')); len(parts) != 1 || parts[0].value != 633 {
+		t.Errorf("partsNextTo('#'):\nwant 633\ngot %v\n", parts)
+	}
+
+	// the '*' next to 617 only has one number, so it's a gear with one part
+	if gears := e.gears(symbolIs('*'), 1); len(gears) != 1 || gears[0].ratio() != 617 {
+		t.Errorf("gears('*', 1):\nwant a single gear with the ratio 617\ngot %v\n", gears)
+	}
+
+	if anomalies := e.anomalies(symbolIs('*'), 2); len(anomalies) != 0 {
+		t.Errorf("anomalies('*', 2):\nwant none\ngot %v\n", anomalies)
+	}
+}
+
+func TestGearAnomalies(t *testing.T) {
+	input := []string{
+		"12.3",
+		"..*.",
+		"ü45.",
+	}
+
+	e := new(engineSchematic)
+	e.new(input)
+
+	// 'ü' is one column even though it's two bytes
+	if p := e.partNumbers[2]; p.value != 45 || p.start != 1 || p.end != 3 {
+		t.Errorf("new():\nwant 45 in columns 1 to 3\ngot %v in %d to %d\n", p.value, p.start, p.end)
+	}
+
+	if sum := day3part2(e, nil); sum != 0 {
+		t.Errorf("day3part2():\nwant 0\ngot %d\n", sum)
+	}
+
+	anomalies := e.anomalies(symbolIs('*'), 2)
+	want := "the '*' at line 2, column 3 is next to 3 numbers (12, 3, 45), but expected at most 2"
+	if len(anomalies) != 1 || anomalies[0].String() != want {
+		t.Errorf("anomalies('*', 2):\nwant %s\ngot %v\n", want, anomalies)
+	}
+
+	if sum := day3part1(e, nil); sum != 60 {
+		t.Errorf("day3part1():\nwant 60\ngot %d\n", sum)
+	}
+}
@@ -5,22 +5,31 @@
 package main
 
 import (
+	"encoding/json"
 	"fileprocessing"
 	"fmt"
+	"io"
 	"log"
-	"math"
+	"os"
 	"strconv"
 	"strings"
+
+	"sciencerocketry.com/explain"
 )
 
+// day4Format is the format of the input - a line for each card
+var day4Format = &inputFormat{
+	sections: []sectionFormat{{
+		lines: &lineFormat{
+			pattern: cardPattern,
+			check:   checkInts("winning", "numbers"),
+		},
+	}},
+}
+
 // day4() has you looking for the source of water and the elf asks you to help him
 // figure out what he's won with his scratch cards
-func day4(name string, inputFile string) string {
-	fileContents, _, err := fileprocessing.ReadFile(inputFile)
-	if err != nil {
-		log.Fatal(err)
-	}
-
+func day4(name string, fileContents []string, ex *explain.Explainer) string {
 	var cards []*card
 	for _, s := range fileContents {
 		c := new(card)
@@ -28,8 +37,8 @@ func day4(name string, inputFile string) string {
 		cards = append(cards, c)
 	}
 
-	sumWorth := day4part1(cards)
-	numberOfCards := day4part2(cards)
+	sumWorth := day4part1(cards, ex)
+	numberOfCards := day4part2(cards, ex)
 
 	var output strings.Builder
 	output.WriteString("***** DAY 4 *****\n")
@@ -39,87 +48,113 @@ func day4(name string, inputFile string) string {
 	output.WriteString("Part 2:\n")
 	output.WriteString("Number of scratch cards with rules: " + strconv.Itoa(numberOfCards))
 
+	if day4BreakdownFile != "" {
+		writeJSONFile(day4BreakdownFile, func(w io.Writer) error {
+			return writeBreakdown(w, cards)
+		})
+
+		output.WriteString("\nThe breakdown of each card was written to " + day4BreakdownFile)
+	}
+
+	if day4TreeFile != "" {
+		writeJSONFile(day4TreeFile, func(w io.Writer) error {
+			return writeSpawnTrees(w, cards, day4TreeDepth)
+		})
+
+		output.WriteString("\nThe tree of cards won was written to " + day4TreeFile)
+	}
+
 	return output.String()
 }
 
+// day4BreakdownFile and day4TreeFile are the files the per-card breakdown and the tree
+// of cards won are written to as JSON, if they are set. day4TreeDepth limits how deep
+// the tree goes. They are set by the '-cards', '-card-tree' and '-card-tree-depth' flags.
+var day4BreakdownFile, day4TreeFile string
+var day4TreeDepth = 3
+
+// writeJSONFile() creates the named file and writes to it with 'write'
+func writeJSONFile(filename string, write func(w io.Writer) error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := write(file); err != nil {
+		file.Close()
+		log.Fatal(err)
+	}
+
+	if err := file.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
+
 type card struct {
 	number         int
 	winningNumbers []int
 	cardNumbers    []int
-	count          int
 }
 
-// new() parses the input string into a card struct
-func (c *card) new(input string) (number int) {
-	parts := strings.Split(input, "|")
-	cardNumbers := strings.Split(strings.TrimSpace(parts[1]), " ")
-	for _, cardNumber := range cardNumbers {
-		if len(cardNumber) > 0 {
-			number, err := strconv.Atoi(cardNumber)
-			if err != nil {
-				log.Fatal(err)
-			}
+// cardPattern matches a line of the input, e.g. 'Card 1: 41 48 83 | 83 86  6'
+var cardPattern = fileprocessing.MustPattern("Card {number:int}: {winning} | {numbers}")
 
-			c.cardNumbers = append(c.cardNumbers, number)
-		}
+// new() parses the input string into a card struct. The numbers can be spaced in any way.
+func (c *card) new(input string) (number int) {
+	var winningNumbers, cardNumbers string
+	if err := cardPattern.Scan(input, &c.number, &winningNumbers, &cardNumbers); err != nil {
+		log.Fatalf("When parsing '%s': %v", input, err)
 	}
 
-	firstPart := strings.Split(parts[0], ":")
-	winningNumbers := strings.Split(strings.TrimSpace(firstPart[1]), " ")
-	for _, winningNumber := range winningNumbers {
-		if len(winningNumber) > 0 {
-			number, err := strconv.Atoi(winningNumber)
-			if err != nil {
-				log.Fatal(err)
-			}
-
-			c.winningNumbers = append(c.winningNumbers, number)
-		}
+	var err error
+	if c.winningNumbers, err = fileprocessing.ParseInts(winningNumbers); err != nil {
+		log.Fatalf("When parsing the winning numbers of '%s': %v", input, err)
 	}
 
-	initialPart := strings.Split(firstPart[0], " ")
-	numStrings := len(initialPart)
-	if numStrings > 0 {
-		cardNumber, err := strconv.Atoi(initialPart[numStrings-1])
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		c.number = cardNumber
+	if c.cardNumbers, err = fileprocessing.ParseInts(cardNumbers); err != nil {
+		log.Fatalf("When parsing the card numbers of '%s': %v", input, err)
 	}
 
-	c.count = 1
-
 	return c.number
 }
 
 // worth() tells you what the score of a given card is given the winning numbers
 // that match the card numbers
 func (c *card) worth() int {
-	count := c.matches()
-	if count == 0 {
+	return worthOf(c.matches())
+}
+
+// worthOf() is the score of a card with 'matches' winning numbers that match
+func worthOf(matches int) int {
+	if matches == 0 {
 		return 0
 	}
 
-	return int(math.Pow(2, float64(count-1)))
+	return 1 << (matches - 1)
 }
 
 // matches() tells you how many winning numbers match the card numbers
 func (c *card) matches() int {
+	return len(c.winners())
+}
+
+// winners() returns the card numbers that match a winning number, in the order they
+// appear on the card
+func (c *card) winners() []int {
 	elementsMap := make(map[int]bool)
 
 	for _, elem := range c.winningNumbers {
 		elementsMap[elem] = true
 	}
 
-	count := 0
+	var winners []int
 	for _, elem := range c.cardNumbers {
 		if _, found := elementsMap[elem]; found {
-			count++
+			winners = append(winners, elem)
 		}
 	}
 
-	return count
+	return winners
 }
 
 // print() prints the value of a given card
@@ -133,11 +168,24 @@ func (c *card) print() {
 }
 
 // day4part1() sums the winning worth of all the cards
-func day4part1(cards []*card) int {
+func day4part1(cards []*card, ex *explain.Explainer) int {
 	sum := 0
 
 	for _, c := range cards {
-		sum += c.worth()
+		winners := c.winners()
+		worth := worthOf(len(winners))
+
+		if len(winners) > 0 {
+			if err := ex.Explain(explain.Explanation{Part: 1, Item: "card " + strconv.Itoa(c.number), Value: worth, Reason: fmt.Sprintf("%d matching numbers %v", len(winners), winners)}); err != nil {
+				log.Fatal(err)
+			}
+		} else {
+			if err := ex.Explain(explain.Explanation{Part: 1, Item: "card " + strconv.Itoa(c.number), Reason: "no matching numbers"}); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		sum += worth
 	}
 
 	return sum
@@ -148,22 +196,159 @@ func day4part1(cards []*card) int {
 // results in having a copy of the next set of cards that equals the number of matches. So if
 // card 1 has 3 matches, then you get a copy of card 2, 3, and 4. If card 2 has 3 matches, you get TWO
 // copies of 3, 4, and 5 because after processing card 1, you now have two card 2s.
-func day4part2(cards []*card) int {
-	numberOfCards := len(cards)
-
-	for i := 0; i < numberOfCards; i++ {
-		matches := cards[i].matches()
-		for j := i + 1; j <= i+matches; j++ {
-			if j < numberOfCards {
-				cards[j].count += cards[i].count
+func day4part2(cards []*card, ex *explain.Explainer) int {
+	matches := matchCounts(cards)
+
+	numberOfCards := 0
+	for i, copies := range cascade(matches) {
+		numberOfCards += copies
+
+		if ex.Enabled() {
+			if err := ex.Explain(explain.Explanation{Part: 2, Item: "card " + strconv.Itoa(cards[i].number), Value: copies, Reason: fmt.Sprintf("each copy wins a copy of cards %v", wonCards(cards, matches, i))}); err != nil {
+				log.Fatal(err)
 			}
 		}
 	}
 
-	numberOfCards = 0
-	for _, card := range cards {
-		numberOfCards += card.count
+	return numberOfCards
+}
+
+// matchCounts() returns the number of matches on each card, so that they're only
+// counted once however many times they're needed
+func matchCounts(cards []*card) []int {
+	matches := make([]int, len(cards))
+	for i, c := range cards {
+		matches[i] = c.matches()
 	}
 
-	return numberOfCards
+	return matches
+}
+
+// cascade() returns how many copies of each card you end up with, including the
+// original, given the number of matches on each card from matchCounts(). Nothing is
+// changed, so it can be called any number of times.
+func cascade(matches []int) []int {
+	copies := make([]int, len(matches))
+	for i := range copies {
+		copies[i] = 1
+	}
+
+	for i := range matches {
+		for _, j := range wins(matches, i) {
+			copies[j] += copies[i]
+		}
+	}
+
+	return copies
+}
+
+// wins() returns the indexes of the cards that the card at index 'i' wins a copy of,
+// given the number of matches on each card
+func wins(matches []int, i int) []int {
+	var won []int
+	for j := i + 1; j <= i+matches[i] && j < len(matches); j++ {
+		won = append(won, j)
+	}
+
+	return won
+}
+
+// wonCards() returns the numbers of the cards that the card at index 'i' wins a copy of
+func wonCards(cards []*card, matches []int, i int) []int {
+	won := []int{}
+	for _, j := range wins(matches, i) {
+		won = append(won, cards[j].number)
+	}
+
+	return won
+}
+
+// cardBreakdown is the result for a single card
+type cardBreakdown struct {
+	Card    int   `json:"card"`
+	Matches int   `json:"matches"`
+	Worth   int   `json:"worth"`
+	Copies  int   `json:"copies"`
+	Wins    []int `json:"wins"`
+}
+
+// breakdown() returns the matches, worth and number of copies of each card, along with
+// the numbers of the cards each one wins a copy of
+func breakdown(cards []*card) []cardBreakdown {
+	matches := matchCounts(cards)
+	copies := cascade(matches)
+
+	result := make([]cardBreakdown, len(cards))
+	for i, c := range cards {
+		result[i] = cardBreakdown{Card: c.number, Matches: matches[i], Worth: worthOf(matches[i]), Copies: copies[i], Wins: wonCards(cards, matches, i)}
+	}
+
+	return result
+}
+
+// writeBreakdown() writes the breakdown of each card as JSON
+func writeBreakdown(w io.Writer, cards []*card) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(breakdown(cards))
+}
+
+// spawnNode is a card in the tree of the cards won from an original card. Total is the
+// number of cards the card ends up being worth, counting itself, the cards it wins, the
+// cards they win and so on. The tree can be very large, so it's cut off at a depth and
+// Truncated is set on the nodes whose wins were left out.
+type spawnNode struct {
+	Card      int          `json:"card"`
+	Total     int          `json:"total"`
+	Wins      []*spawnNode `json:"wins,omitempty"`
+	Truncated bool         `json:"truncated,omitempty"`
+}
+
+// spawnTrees() returns the tree of cards won from each of the original cards, down to
+// 'depth' levels of wins
+func spawnTrees(cards []*card, depth int) []*spawnNode {
+	matches := matchCounts(cards)
+
+	// the total for each card is the same wherever it appears in a tree, and working
+	// backwards means the totals of the cards it wins are already known
+	totals := make([]int, len(cards))
+	for i := len(cards) - 1; i >= 0; i-- {
+		totals[i] = 1
+		for _, j := range wins(matches, i) {
+			totals[i] += totals[j]
+		}
+	}
+
+	var grow func(i int, depth int) *spawnNode
+	grow = func(i int, depth int) *spawnNode {
+		node := &spawnNode{Card: cards[i].number, Total: totals[i]}
+
+		won := wins(matches, i)
+		if depth == 0 {
+			node.Truncated = len(won) > 0
+			return node
+		}
+
+		for _, j := range won {
+			node.Wins = append(node.Wins, grow(j, depth-1))
+		}
+
+		return node
+	}
+
+	trees := make([]*spawnNode, len(cards))
+	for i := range cards {
+		trees[i] = grow(i, depth)
+	}
+
+	return trees
+}
+
+// writeSpawnTrees() writes the tree of cards won from each of the original cards as JSON
+func writeSpawnTrees(w io.Writer, cards []*card, depth int) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(spawnTrees(cards, depth))
 }
@@ -1,9 +1,33 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 )
 
+var sampleCards = []string{
+	"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+	"Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19",
+	"Card 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1",
+	"Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83",
+	"Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36",
+	"Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11",
+}
+
+// parseSampleCards() parses the example cards from the puzzle
+func parseSampleCards() []*card {
+	var cards []*card
+	for _, input := range sampleCards {
+		c := new(card)
+		_ = c.new(input)
+		cards = append(cards, c)
+	}
+
+	return cards
+}
+
 func TestSumOfWinningScratchcards(t *testing.T) {
 	var tests = []struct {
 		card   string
@@ -31,7 +55,7 @@ func TestSumOfWinningScratchcards(t *testing.T) {
 		}
 	}
 
-	sum := day4part1(cards)
+	sum := day4part1(cards, nil)
 	expectedSum := 13
 
 	if sum != expectedSum {
@@ -59,10 +83,95 @@ func TestTotalNumberOfCardsFollowingTheRules(t *testing.T) {
 		cards = append(cards, c)
 	}
 
-	numberOfCards := day4part2(cards)
+	numberOfCards := day4part2(cards, nil)
 	expectedNumberOfCards := 30
 
 	if numberOfCards != expectedNumberOfCards {
 		t.Errorf("expected # of cards %d\nmy # of cards %d", expectedNumberOfCards, numberOfCards)
 	}
 }
+
+// TestCascade() checks the copies of each card and that working them out doesn't change
+// the cards, so part 2 gives the same answer every time
+func TestCascade(t *testing.T) {
+	cards := parseSampleCards()
+
+	copies := fmt.Sprint(cascade(matchCounts(cards)))
+	expectedCopies := "[1 2 4 8 14 1]"
+	if copies != expectedCopies {
+		t.Errorf("cascade():\nwant %v\ngot %v\n", expectedCopies, copies)
+	}
+
+	for i := 0; i < 2; i++ {
+		if numberOfCards := day4part2(cards, nil); numberOfCards != 30 {
+			t.Errorf("day4part2() call %d:\nwant 30\ngot %v\n", i+1, numberOfCards)
+		}
+	}
+}
+
+func TestBreakdown(t *testing.T) {
+	var output strings.Builder
+	if err := writeBreakdown(&output, parseSampleCards()); err != nil {
+		t.Fatal(err)
+	}
+
+	var result []cardBreakdown
+	if err := json.Unmarshal([]byte(output.String()), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		matches int
+		worth   int
+		copies  int
+		wins    string
+	}{
+		{4, 8, 1, "[2 3 4 5]"},
+		{2, 2, 2, "[3 4]"},
+		{2, 2, 4, "[4 5]"},
+		{1, 1, 8, "[5]"},
+		{0, 0, 14, "[]"},
+		{0, 0, 1, "[]"},
+	}
+
+	if len(result) != len(tests) {
+		t.Fatalf("writeBreakdown():\nwant %d cards\ngot %d\n", len(tests), len(result))
+	}
+
+	for i, test := range tests {
+		b := result[i]
+		if b.Card != i+1 || b.Matches != test.matches || b.Worth != test.worth || b.Copies != test.copies || fmt.Sprint(b.Wins) != test.wins {
+			t.Errorf("writeBreakdown() card %d:\nwant %+v\ngot %+v\n", i+1, test, b)
+		}
+	}
+}
+
+func TestSpawnTrees(t *testing.T) {
+	trees := spawnTrees(parseSampleCards(), 1)
+
+	// every copy of a card is won by exactly one card, so the totals of the
+	// original cards add up to the answer to part 2
+	var totals []int
+	sum := 0
+	for _, tree := range trees {
+		totals = append(totals, tree.Total)
+		sum += tree.Total
+	}
+
+	if fmt.Sprint(totals) != "[15 7 4 2 1 1]" || sum != 30 {
+		t.Errorf("spawnTrees() totals:\nwant [15 7 4 2 1 1]\ngot %v\n", totals)
+	}
+
+	first := trees[0]
+	if len(first.Wins) != 4 || first.Truncated {
+		t.Fatalf("spawnTrees() card 1:\nwant 4 wins that aren't truncated\ngot %+v\n", first)
+	}
+
+	if won := first.Wins[0]; won.Card != 2 || won.Total != 7 || len(won.Wins) != 0 || !won.Truncated {
+		t.Errorf("spawnTrees() card 2 won by card 1:\nwant card 2, a total of 7 and truncated\ngot %+v\n", won)
+	}
+
+	if won := first.Wins[3]; won.Card != 5 || won.Truncated {
+		t.Errorf("spawnTrees() card 5 won by card 1:\nwant card 5 without any wins to truncate\ngot %+v\n", won)
+	}
+}
@@ -13,8 +13,24 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+
+	"sciencerocketry.com/explain"
 )
 
+// day5Format is the format of the input - the seeds followed by a section for each map
+var day5Format = &inputFormat{
+	sections: []sectionFormat{
+		{
+			header: &lineFormat{pattern: seedsPattern, check: checkInts("seeds")},
+		},
+		{
+			header: &lineFormat{pattern: mapHeaderPattern},
+			lines:  &lineFormat{pattern: fileprocessing.MustPattern("{destination:int} {source:int} {length:int}")},
+		},
+	},
+	repeat: true,
+}
+
 // src_dest_map is a structure that tracks the individual source-to-destination mappings
 type src_dest_map struct {
 	destination_start int
@@ -36,26 +52,33 @@ type almanac struct {
 	maps  []*almanac_map
 }
 
-// almanac_map.new() parses the input of a map section into an almanac_map structure
-func (m *almanac_map) new(input []string) {
-	if len(input) <= 0 {
-		log.Fatal("no almanac_map input to process")
+// mapHeaderPattern matches the header of a map section, e.g. 'fertilizer-to-water map:'
+var mapHeaderPattern = fileprocessing.MustPattern("{source}-to-{destination} map:")
+
+// seedsPattern matches the first line of the almanac, e.g. 'seeds: 79 14 55 13'
+var seedsPattern = fileprocessing.MustPattern("seeds: {seeds}")
+
+// almanac_map.new() parses a map section of the input into an almanac_map structure
+func (m *almanac_map) new(section fileprocessing.Section) {
+	if err := mapHeaderPattern.Scan(section.Header, &m.source, &m.destination); err != nil {
+		log.Fatal(section.Errorf(-1, "%w", err))
 	}
 
-	// parsing a string like "fertilizer-to-water map:" to extract "fertilizer" and "map"
-	header := strings.Split(input[0], " ")
-	mapping := strings.Split(header[0], "-")
+	for i, s := range section.Lines {
+		mappingVals, err := fileprocessing.ParseInts(s)
+		if err != nil {
+			log.Fatal(section.Errorf(i, "%w", err))
+		}
 
-	m.source = mapping[0]
-	m.destination = mapping[2]
+		if len(mappingVals) != 3 {
+			log.Fatal(section.Errorf(i, "expected a destination start, source start and range length"))
+		}
 
-	for _, s := range input[1:] {
-		var numMap src_dest_map
-		mappingVals := strings.Split(s, " ")
-		numMap.destination_start, _ = strconv.Atoi(mappingVals[0])
-		numMap.source_start, _ = strconv.Atoi(mappingVals[1])
-		numMap.range_length, _ = strconv.Atoi(mappingVals[2])
-		m.mappings = append(m.mappings, numMap)
+		m.mappings = append(m.mappings, src_dest_map{
+			destination_start: mappingVals[0],
+			source_start:      mappingVals[1],
+			range_length:      mappingVals[2],
+		})
 	}
 }
 
@@ -96,38 +119,27 @@ func (m *almanac_map) navigate(source int) int {
 	return source
 }
 
-// almanac.new() parses the input file into an almanac structure
+// almanac.new() parses the input file into an almanac structure. The seeds are on the
+// first line and each map is a section headed by its name, separated by blank lines.
 func (a *almanac) new(input []string) {
-	if len(input) <= 0 {
+	sections := fileprocessing.HeadedSections(input)
+	if len(sections) == 0 {
 		log.Fatal("no almanac input to process")
 	}
 
-	seedsInput := strings.Split(input[0], " ")
-	for i := 1; i < len(seedsInput); i++ {
-		seed, err := strconv.Atoi(seedsInput[i])
-		if err != nil {
-			log.Fatal("There was an error processing the seeds input")
-		}
-		a.seeds = append(a.seeds, seed)
+	var seeds string
+	if err := seedsPattern.Scan(sections[0].Header, &seeds); err != nil {
+		log.Fatal(sections[0].Errorf(-1, "%w", err))
 	}
 
-	startIndex, endIndex, lengthInput := 2, 0, len(input)
-
-	for currentIndex := 2; currentIndex < lengthInput; currentIndex++ {
-		if len(input[currentIndex]) <= 0 {
-			endIndex = currentIndex
-
-			m := new(almanac_map)
-			m.new(input[startIndex:endIndex])
-			a.maps = append(a.maps, m)
-
-			startIndex = currentIndex + 1
-		}
+	var err error
+	if a.seeds, err = fileprocessing.ParseInts(seeds); err != nil {
+		log.Fatal(sections[0].Errorf(-1, "the seeds: %w", err))
 	}
 
-	if startIndex < lengthInput {
+	for _, section := range sections[1:] {
 		m := new(almanac_map)
-		m.new(input[startIndex:])
+		m.new(section)
 		a.maps = append(a.maps, m)
 	}
 }
@@ -166,29 +178,24 @@ func (a *almanac) seedTo(seed int, to string) (int, error) {
 
 // day5() has you helping Island Island with their food production problem described
 // in the assignment
-func day5(name string, inputFile string) string {
-	fileContents, _, err := fileprocessing.ReadFile(inputFile)
-	if err != nil {
-		log.Fatal(err)
-	}
-
+func day5(name string, fileContents []string, ex *explain.Explainer) string {
 	a := new(almanac)
 	a.new(fileContents)
 
 	var output strings.Builder
 	output.WriteString("***** DAY 5 *****\n")
 	output.WriteString("Part 1:\n")
-	output.WriteString("Lowest Location: " + day5part1(a))
+	output.WriteString("Lowest Location: " + day5part1(a, ex))
 	output.WriteString("\n")
 	output.WriteString("Part 2:\n")
-	output.WriteString("Lowest Location: " + day5part2(a))
+	output.WriteString("Lowest Location: " + day5part2(a, ex))
 
 	return output.String()
 }
 
 // day5part1() has you traversing the maps on the almanac to find the lowest "location" number for
 // the given seed values
-func day5part1(a *almanac) string {
+func day5part1(a *almanac, ex *explain.Explainer) string {
 	lowestLocation := math.MaxInt64
 
 	for _, seed := range a.seeds {
@@ -197,6 +204,12 @@ func day5part1(a *almanac) string {
 			log.Fatal("There was an error using seedTo()")
 		}
 
+		if ex.Enabled() {
+			if err := ex.Explain(explain.Explanation{Part: 1, Item: "seed " + strconv.Itoa(seed), Value: val, Reason: a.route(seed)}); err != nil {
+				log.Fatal(err)
+			}
+		}
+
 		if val < lowestLocation {
 			lowestLocation = val
 		}
@@ -205,11 +218,25 @@ func day5part1(a *almanac) string {
 	return strconv.Itoa(lowestLocation)
 }
 
+// almanac.route() describes the number the seed maps to in each of the maps in turn,
+// e.g. 'soil 81, fertilizer 81, water 81, ...'
+func (a *almanac) route(seed int) string {
+	var route []string
+
+	result := seed
+	for _, m := range a.maps {
+		result = m.navigate(result)
+		route = append(route, m.destination+" "+strconv.Itoa(result))
+	}
+
+	return strings.Join(route, ", ")
+}
+
 // day5part2() has you traversing the maps on the almanac to find the lowest "location" number for
 // the given seed values but the seed values are a massive range
 //
 // note - current implementation is brute force but there are more efficient ways to solve this
-func day5part2(a *almanac) string {
+func day5part2(a *almanac, ex *explain.Explainer) string {
 	lowestLocation := math.MaxInt64
 
 	numberOfSeedPairs := len(a.seeds) / 2
@@ -219,15 +246,19 @@ func day5part2(a *almanac) string {
 	for i := 0; i < numberOfSeedPairs; i++ {
 		start := a.seeds[i*2]
 		end := a.seeds[i*2] + a.seeds[i*2+1]
+		lowestInRange := math.MaxInt64
 		for seed := start; seed < end; seed++ {
 			val, err := a.seedTo(seed, "location")
 			if err != nil {
 				log.Fatal("There was an error using seedTo()")
 			}
 
-			if val < lowestLocation {
-				lowestLocation = val
-			}
+			lowestInRange = min(lowestInRange, val)
+		}
+
+		lowestLocation = min(lowestLocation, lowestInRange)
+		if err := ex.Explain(explain.Explanation{Part: 2, Item: "seeds " + strconv.Itoa(start) + " to " + strconv.Itoa(end-1), Value: lowestInRange, Reason: fmt.Sprintf("the lowest location of the %d seeds", end-start)}); err != nil {
+			log.Fatal(err)
 		}
 
 		fmt.Print("\r\033[2K")
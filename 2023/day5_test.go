@@ -52,7 +52,7 @@ humidity-to-location map:
 	// 	fmt.Printf("seedTo(): %d, 'location': %d\n", seed, val)
 	// }
 
-	result := day5part1(a)
+	result := day5part1(a, nil)
 	expectedResult := "35"
 	if result != expectedResult {
 		t.Errorf("expected: %s, got: %s", expectedResult, result)
@@ -106,7 +106,7 @@ humidity-to-location map:
 	// 	fmt.Printf("seedTo(): %d, 'location': %d\n", seed, val)
 	// }
 
-	result := day5part2(a)
+	result := day5part2(a, nil)
 	expectedResult := "46"
 	if result != expectedResult {
 		t.Errorf("expected: %s, got: %s", expectedResult, result)
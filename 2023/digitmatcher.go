@@ -0,0 +1,228 @@
+// this file implements the multi-pattern matcher used by Day 1 to find digits, whether
+// they are written as numerals or spelled out, in a single pass over a line.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// vocabulary maps each word the matcher looks for to the digit it stands for
+type vocabulary map[string]int
+
+// numerals are the digits 1 to 9 written as numerals. Zero isn't included because
+// the calibration values never use it.
+var numerals = vocabulary{"1": 1, "2": 2, "3": 3, "4": 4, "5": 5, "6": 6, "7": 7, "8": 8, "9": 9}
+
+// spelledOut holds the spelled-out digits 1 to 9 in a few languages
+var spelledOut = map[string]vocabulary{
+	"english": {"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9},
+	"french":  {"un": 1, "deux": 2, "trois": 3, "quatre": 4, "cinq": 5, "six": 6, "sept": 7, "huit": 8, "neuf": 9},
+	"german":  {"eins": 1, "zwei": 2, "drei": 3, "vier": 4, "fünf": 5, "sechs": 6, "sieben": 7, "acht": 8, "neun": 9},
+	"spanish": {"uno": 1, "dos": 2, "tres": 3, "cuatro": 4, "cinco": 5, "seis": 6, "siete": 7, "ocho": 8, "nueve": 9},
+}
+
+// zero holds zero as a numeral and spelled out, for vocabularies that need it
+var zero = vocabulary{"0": 0, "zero": 0}
+
+// digitMatch is a word found in a line. 'start' and 'end' are byte offsets into the
+// line, so line[start:end] is the word.
+type digitMatch struct {
+	word  string
+	digit int
+	start int
+	end   int
+}
+
+// digitMatch.String() describes a match for debugging, e.g. 'eight=8 at 3-8'
+func (m digitMatch) String() string {
+	return fmt.Sprintf("%s=%d at %d-%d", m.word, m.digit, m.start, m.end)
+}
+
+// matcherNode is a state in the matcher - the prefix of one or more words
+type matcherNode struct {
+	children map[byte]int
+	// fail is the node for the longest proper suffix of this prefix that is also a prefix
+	fail int
+	// word is the index of the word that ends at this node, or -1
+	word int
+	// output is the nearest node along the fail links that ends a word, or -1
+	output int
+}
+
+// digitMatcher finds every word of its vocabulary in a line in a single pass, using the
+// Aho-Corasick algorithm. Matches can overlap, so 'eightwo' has both 'eight' and 'two'.
+// Lines are matched byte by byte, so words and lines can contain any UTF-8 text.
+type digitMatcher struct {
+	nodes  []matcherNode
+	words  []string
+	digits []int
+}
+
+// newDigitMatcher() builds a matcher for the words of all of the vocabularies. A word
+// can appear in more than one vocabulary, but only if it stands for the same digit.
+func newDigitMatcher(vocabularies ...vocabulary) (*digitMatcher, error) {
+	merged := vocabulary{}
+	for _, v := range vocabularies {
+		for word, digit := range v {
+			if len(word) == 0 {
+				return nil, fmt.Errorf("a vocabulary has an empty word")
+			}
+
+			if existing, found := merged[word]; found && existing != digit {
+				return nil, fmt.Errorf("'%s' is both %d and %d", word, existing, digit)
+			}
+
+			merged[word] = digit
+		}
+	}
+
+	m := &digitMatcher{nodes: []matcherNode{{children: map[byte]int{}, word: -1, output: -1}}}
+
+	// add the words in order so the matcher is the same every time it's built
+	for word := range merged {
+		m.words = append(m.words, word)
+	}
+
+	sort.Strings(m.words)
+
+	for i, word := range m.words {
+		m.digits = append(m.digits, merged[word])
+
+		node := 0
+		for j := 0; j < len(word); j++ {
+			child, found := m.nodes[node].children[word[j]]
+			if !found {
+				child = len(m.nodes)
+				m.nodes = append(m.nodes, matcherNode{children: map[byte]int{}, word: -1, output: -1})
+				m.nodes[node].children[word[j]] = child
+			}
+
+			node = child
+		}
+
+		m.nodes[node].word = i
+	}
+
+	// set the fail and output links breadth first, so the links of the shorter
+	// prefixes are ready when they're needed
+	queue := []int{}
+	for _, child := range m.nodes[0].children {
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for c, child := range m.nodes[node].children {
+			m.nodes[child].fail = m.step(m.nodes[node].fail, c)
+			if fail := m.nodes[child].fail; m.nodes[fail].word >= 0 {
+				m.nodes[child].output = fail
+			} else {
+				m.nodes[child].output = m.nodes[fail].output
+			}
+
+			queue = append(queue, child)
+		}
+	}
+
+	return m, nil
+}
+
+// mustDigitMatcher() builds a matcher like newDigitMatcher(), but panics if the
+// vocabularies disagree. It's meant for matchers in package variables.
+func mustDigitMatcher(vocabularies ...vocabulary) *digitMatcher {
+	m, err := newDigitMatcher(vocabularies...)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// digitMatcher.step() returns the node reached from 'node' by the byte 'c'
+func (m *digitMatcher) step(node int, c byte) int {
+	for {
+		if child, found := m.nodes[node].children[c]; found {
+			return child
+		}
+
+		if node == 0 {
+			return 0
+		}
+
+		node = m.nodes[node].fail
+	}
+}
+
+// digitMatcher.findAll() returns every match in the line, including overlapping ones, in
+// a single pass. They're ordered by where they end, so use firstMatch() and lastMatch()
+// to find the ones that start first and last.
+func (m *digitMatcher) findAll(line string) []digitMatch {
+	var matches []digitMatch
+
+	node := 0
+	for i := 0; i < len(line); i++ {
+		node = m.step(node, line[i])
+
+		found := node
+		if m.nodes[found].word < 0 {
+			found = m.nodes[found].output
+		}
+
+		for ; found >= 0; found = m.nodes[found].output {
+			word := m.words[m.nodes[found].word]
+			matches = append(matches, digitMatch{word: word, digit: m.digits[m.nodes[found].word], start: i + 1 - len(word), end: i + 1})
+		}
+	}
+
+	return matches
+}
+
+// firstMatch() returns the match that starts first. If there are no matches, ok is false.
+func firstMatch(matches []digitMatch) (match digitMatch, ok bool) {
+	for i, m := range matches {
+		if i == 0 || m.start < match.start {
+			match = m
+		}
+	}
+
+	return match, len(matches) > 0
+}
+
+// lastMatch() returns the match that starts last. Of two matches that start in the same
+// place, the longer one is last. If there are no matches, ok is false.
+func lastMatch(matches []digitMatch) (match digitMatch, ok bool) {
+	for i, m := range matches {
+		if i == 0 || m.start >= match.start {
+			match = m
+		}
+	}
+
+	return match, len(matches) > 0
+}
+
+// digitMatcher.first() returns the match that starts first in the line. If nothing
+// matches, ok is false.
+func (m *digitMatcher) first(line string) (match digitMatch, ok bool) {
+	return firstMatch(m.findAll(line))
+}
+
+// digitMatcher.last() returns the match that starts last in the line. If nothing
+// matches, ok is false.
+func (m *digitMatcher) last(line string) (match digitMatch, ok bool) {
+	return lastMatch(m.findAll(line))
+}
+
+// describeMatches() lists the matches in a line, e.g. 'eight=8 at 0-5, two=2 at 4-7'
+func describeMatches(matches []digitMatch) string {
+	var descriptions []string
+	for _, match := range matches {
+		descriptions = append(descriptions, match.String())
+	}
+
+	return strings.Join(descriptions, ", ")
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	var tests = []struct {
+		vocabularies []vocabulary
+		line         string
+		result       string
+	}{
+		{[]vocabulary{numerals, spelledOut["english"]}, "eightwo", "eight=8 at 0-5, two=2 at 4-7"},
+		{[]vocabulary{numerals, spelledOut["english"]}, "xtwone3four", "two=2 at 1-4, one=1 at 3-6, 3=3 at 6-7, four=4 at 7-11"},
+		{[]vocabulary{numerals, spelledOut["english"]}, "oneight0", "one=1 at 0-3, eight=8 at 2-7"},
+		{[]vocabulary{numerals, spelledOut["english"], zero}, "oneight0", "one=1 at 0-3, eight=8 at 2-7, 0=0 at 7-8"},
+		{[]vocabulary{numerals, spelledOut["english"]}, "nothing here", ""},
+		{[]vocabulary{numerals, spelledOut["english"]}, "ééseven→9", "seven=7 at 4-9, 9=9 at 12-13"},
+		{[]vocabulary{spelledOut["german"]}, "fünfeinsacht", "fünf=5 at 0-5, eins=1 at 5-9, acht=8 at 9-13"},
+		{[]vocabulary{spelledOut["french"]}, "deuxneufun", "deux=2 at 0-4, neuf=9 at 4-8, un=1 at 8-10"},
+		{[]vocabulary{{"ab": 1, "bab": 2, "b": 3}}, "abab", "ab=1 at 0-2, b=3 at 1-2, bab=2 at 1-4, ab=1 at 2-4, b=3 at 3-4"},
+	}
+
+	for _, test := range tests {
+		m, err := newDigitMatcher(test.vocabularies...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		value := describeMatches(m.findAll(test.line))
+		if value != test.result {
+			t.Errorf("findAll(%s):\nwant %v\ngot %v\n", test.line, test.result, value)
+		}
+	}
+}
+
+func TestFirstAndLast(t *testing.T) {
+	m := mustDigitMatcher(numerals, spelledOut["english"])
+
+	first, ok := m.first("zoneight234")
+	if !ok || first.digit != 1 || first.start != 1 {
+		t.Errorf("first():\nwant one at 1\ngot %v\n", first)
+	}
+
+	last, ok := m.last("7pqrstsixteen")
+	if !ok || last.digit != 6 || last.start != 6 {
+		t.Errorf("last():\nwant six at 6\ngot %v\n", last)
+	}
+
+	// 'b' is found before 'abc' because it ends first, but 'abc' starts first
+	nested := mustDigitMatcher(vocabulary{"abc": 1, "b": 2})
+	if first, _ := nested.first("abc"); first.word != "abc" {
+		t.Errorf("first():\nwant abc at 0\ngot %v\n", first)
+	}
+
+	if last, _ := nested.last("abc"); last.word != "b" {
+		t.Errorf("last():\nwant b at 1\ngot %v\n", last)
+	}
+
+	if _, ok := m.first("abc"); ok {
+		t.Errorf("first():\nwant no match\ngot a match\n")
+	}
+}
+
+func TestNewDigitMatcher(t *testing.T) {
+	if _, err := newDigitMatcher(numerals, vocabulary{"1": 7}); err == nil {
+		t.Errorf("newDigitMatcher() with '1' as two digits: expected an error\n")
+	}
+
+	if _, err := newDigitMatcher(vocabulary{"": 1}); err == nil {
+		t.Errorf("newDigitMatcher() with an empty word: expected an error\n")
+	}
+
+	if _, err := newDigitMatcher(numerals, numerals, spelledOut["english"]); err != nil {
+		t.Errorf("newDigitMatcher() with a repeated vocabulary:\nwant no error\ngot %v\n", err)
+	}
+}
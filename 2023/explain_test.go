@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sciencerocketry.com/explain"
+)
+
+// explainTo() returns an Explainer for an exercise called 'Day N' that writes to 'output'
+func explainTo(t *testing.T, output *strings.Builder, format string) *explain.Explainer {
+	e, err := explain.New(output, format)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return e.ForExercise("Day N")
+}
+
+func TestExplainText(t *testing.T) {
+	var output strings.Builder
+	day2part1(sampleGames(), explainTo(t, &output, "text"))
+
+	expected := []string{
+		"Day N part 1 - game 1: 1 (possible)",
+		"Day N part 1 - game 2: 2 (possible)",
+		"Day N part 1 - game 3: 0 (impossible, set 1 has 20 red but the bag holds 12)",
+		"Day N part 1 - game 4: 0 (impossible, set 3 has 15 blue but the bag holds 14)",
+		"Day N part 1 - game 5: 5 (possible)",
+	}
+
+	if strings.TrimSpace(output.String()) != strings.Join(expected, "\n") {
+		t.Errorf("Explain() day2part1():\nwant %q\ngot  %q\n", expected, output.String())
+	}
+}
+
+func TestExplainJSON(t *testing.T) {
+	var output strings.Builder
+	day4part1(parseSampleCards(), explainTo(t, &output, "json"))
+
+	var tests = []struct {
+		value  int
+		reason string
+	}{
+		{8, "4 matching numbers [83 86 17 48]"},
+		{2, "2 matching numbers [61 32]"},
+		{2, "2 matching numbers [21 1]"},
+		{1, "1 matching numbers [84]"},
+		{0, "no matching numbers"},
+		{0, "no matching numbers"},
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != len(tests) {
+		t.Fatalf("Explain() day4part1():\nwant %d lines\ngot  %q\n", len(tests), lines)
+	}
+
+	for i, test := range tests {
+		var ex explain.Explanation
+		if err := json.Unmarshal([]byte(lines[i]), &ex); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := explain.Explanation{Exercise: "Day N", Part: 1, Item: "card " + string(rune('1'+i)), Value: test.value, Reason: test.reason}
+		if !reflect.DeepEqual(ex, expected) {
+			t.Errorf("Explain() day4part1() card %d:\nwant %+v\ngot  %+v\n", i+1, expected, ex)
+		}
+	}
+}
+
+func TestExplainerOff(t *testing.T) {
+	// with no explainer, the parts still work and nothing is explained
+	if sum := day2part1(sampleGames(), nil); sum != 8 {
+		t.Errorf("day2part1() without explanations:\nwant 8\ngot %v\n", sum)
+	}
+
+	if explanations.ForExercise("Day N") != nil {
+		t.Errorf("ForExercise() without explanations:\nwant nil\ngot an Explainer\n")
+	}
+}
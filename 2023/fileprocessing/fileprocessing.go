@@ -1,27 +1,21 @@
 package fileprocessing
 
-import (
-	"bufio"
-	"os"
-)
-
+// ReadFile() reads every line of the named file, returning the lines and the number
+// of lines. The file is read a line at a time and normalised by EachNormalisedLine(),
+// so a byte order mark, '\r' line endings and trailing whitespace never reach the
+// solutions.
 func ReadFile(filename string) ([]string, int, error) {
-	file, err := os.Open(filename)
+	return ReadFileLimited(filename, 0)
+}
+
+// ReadFileLimited() reads every line of the named file like ReadFile(), but stops with
+// ErrLineTooLong at a line longer than 'maxLineSize' bytes. A 'maxLineSize' of 0 means
+// there's no limit.
+func ReadFileLimited(filename string, maxLineSize int) ([]string, int, error) {
+	lines, _, err := readNormalised(filename, maxLineSize)
 	if err != nil {
 		return nil, -1, err
 	}
 
-	defer func() {
-		if err := file.Close(); err != nil {
-			panic(err)
-		}
-	}()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-
 	return lines, len(lines), nil
 }
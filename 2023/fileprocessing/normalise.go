@@ -0,0 +1,182 @@
+package fileprocessing
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// byteOrderMark is the UTF-8 encoding of U+FEFF, which some editors add to the start
+// of a file.
+const byteOrderMark = "\uFEFF"
+
+// Report records the changes Normalise() made to an input. Line numbers start from 1.
+type Report struct {
+	// ByteOrderMark is set if a byte order mark was removed from the start of the input.
+	ByteOrderMark bool
+	// CarriageReturns are the lines a '\r' was removed from.
+	CarriageReturns []int
+	// TrailingWhitespace are the lines that had other whitespace removed from their end.
+	TrailingWhitespace []int
+	// MissingFinalNewline is set if the last line didn't end with a newline.
+	MissingFinalNewline bool
+}
+
+// Changed() reports whether the input was changed. A missing final newline doesn't
+// change the lines, but it's still reported as a change.
+func (r Report) Changed() bool {
+	return r.ByteOrderMark || len(r.CarriageReturns) > 0 || len(r.TrailingWhitespace) > 0 || r.MissingFinalNewline
+}
+
+// String() describes the changes, e.g. "removed '\r' from 3 lines (1, 2, 3)".
+func (r Report) String() string {
+	var changes []string
+	if r.ByteOrderMark {
+		changes = append(changes, "removed a byte order mark")
+	}
+
+	if len(r.CarriageReturns) > 0 {
+		changes = append(changes, "removed '\\r' from "+describeLines(r.CarriageReturns))
+	}
+
+	if len(r.TrailingWhitespace) > 0 {
+		changes = append(changes, "trimmed trailing whitespace from "+describeLines(r.TrailingWhitespace))
+	}
+
+	if r.MissingFinalNewline {
+		changes = append(changes, "added a final newline")
+	}
+
+	if len(changes) == 0 {
+		return "no changes"
+	}
+
+	return strings.Join(changes, "; ")
+}
+
+// describeLines() describes a list of line numbers, only listing the first few.
+func describeLines(lineNumbers []int) string {
+	const maxListed = 5
+
+	description := "1 line"
+	if len(lineNumbers) > 1 {
+		description = fmt.Sprintf("%d lines", len(lineNumbers))
+	}
+
+	var listed []string
+	for _, n := range lineNumbers[:min(len(lineNumbers), maxListed)] {
+		listed = append(listed, fmt.Sprint(n))
+	}
+
+	if len(lineNumbers) > maxListed {
+		listed = append(listed, "...")
+	}
+
+	return description + " (" + strings.Join(listed, ", ") + ")"
+}
+
+// normaliser normalises the lines of an input one at a time, recording what it changed.
+type normaliser struct {
+	report     Report
+	lineNumber int
+}
+
+// normaliser.line() normalises a line read by a LineReader, given the line ending that
+// was removed from it. It returns false if there's nothing left of the line, which
+// only happens for an input that's just a byte order mark.
+func (n *normaliser) line(line string, ending string) (string, bool) {
+	n.lineNumber++
+
+	if n.lineNumber == 1 {
+		if trimmed, found := strings.CutPrefix(line, byteOrderMark); found {
+			line = trimmed
+			n.report.ByteOrderMark = true
+		}
+
+		if len(line) == 0 && len(ending) == 0 {
+			return "", false
+		}
+	}
+
+	if strings.HasPrefix(ending, "\r") {
+		n.report.CarriageReturns = append(n.report.CarriageReturns, n.lineNumber)
+	}
+
+	if !strings.HasSuffix(ending, "\n") {
+		n.report.MissingFinalNewline = true
+	}
+
+	if trimmed := strings.TrimRightFunc(line, unicode.IsSpace); len(trimmed) != len(line) {
+		n.report.TrailingWhitespace = append(n.report.TrailingWhitespace, n.lineNumber)
+		line = trimmed
+	}
+
+	return line, true
+}
+
+// EachNormalisedLine() calls 'f' with each line of the LineReader and its line number
+// after normalising the line, stopping at the first error from reading or from 'f'. A
+// byte order mark is removed from the start of the input and the '\r' and any other
+// whitespace from the end of each line. A final newline is optional. The Report says
+// what had to be changed.
+func EachNormalisedLine(lr *LineReader, f func(lineNumber int, line string) error) (Report, error) {
+	var n normaliser
+	for lr.Scan() {
+		line, ok := n.line(lr.Text(), lr.Ending())
+		if !ok {
+			continue
+		}
+
+		if err := f(lr.LineNumber(), line); err != nil {
+			return n.report, err
+		}
+	}
+
+	return n.report, lr.Err()
+}
+
+// Normalise() splits an input into lines and normalises them like EachNormalisedLine().
+func Normalise(contents string) ([]string, Report) {
+	var lines []string
+
+	// reading from a string can't fail
+	r, _ := EachNormalisedLine(NewLineReader(strings.NewReader(contents)), func(_ int, line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+
+	return lines, r
+}
+
+// ReadNormalised() reads the named file a line at a time, normalising it like
+// EachNormalisedLine().
+func ReadNormalised(filename string) ([]string, Report, error) {
+	return readNormalised(filename, 0)
+}
+
+// readNormalised() reads and normalises the named file like ReadNormalised(), but stops
+// with ErrLineTooLong at a line longer than 'maxLineSize' bytes, unless it's 0.
+func readNormalised(filename string, maxLineSize int) ([]string, Report, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, Report{}, err
+	}
+
+	defer file.Close()
+
+	lr := NewLineReader(file)
+	lr.MaxLineSize = maxLineSize
+
+	var lines []string
+	r, err := EachNormalisedLine(lr, func(_ int, line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+
+	if err != nil {
+		return nil, r, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return lines, r, nil
+}
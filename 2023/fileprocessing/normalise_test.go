@@ -0,0 +1,75 @@
+package fileprocessing
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var normaliseTests = []struct {
+	input  string
+	lines  []string
+	report Report
+	text   string
+}{
+	{"", nil, Report{}, "no changes"},
+	{"a\nb\n", []string{"a", "b"}, Report{}, "no changes"},
+	{"a\n\n", []string{"a", ""}, Report{}, "no changes"},
+	{"a\nb", []string{"a", "b"}, Report{MissingFinalNewline: true}, "added a final newline"},
+	{"\uFEFF", nil, Report{ByteOrderMark: true}, "removed a byte order mark"},
+	{"a\r", []string{"a"}, Report{CarriageReturns: []int{1}, MissingFinalNewline: true}, "removed '\\r' from 1 line (1); added a final newline"},
+	{
+		"\uFEFF12 34\r\n5 6 \r\n7\t\n", []string{"12 34", "5 6", "7"},
+		Report{ByteOrderMark: true, CarriageReturns: []int{1, 2}, TrailingWhitespace: []int{2, 3}},
+		"removed a byte order mark; removed '\\r' from 2 lines (1, 2); trimmed trailing whitespace from 2 lines (2, 3)",
+	},
+	{
+		"1\r\n2\r\n3\r\n4\r\n5\r\n6\r\n", []string{"1", "2", "3", "4", "5", "6"},
+		Report{CarriageReturns: []int{1, 2, 3, 4, 5, 6}},
+		"removed '\\r' from 6 lines (1, 2, 3, 4, 5, ...)",
+	},
+}
+
+// TestNormalise() checks each of the changes and how they're reported.
+func TestNormalise(t *testing.T) {
+	for _, test := range normaliseTests {
+		lines, report := Normalise(test.input)
+		if !reflect.DeepEqual(lines, test.lines) {
+			t.Errorf("Normalise(%q):\nwant %q\ngot  %q\n", test.input, test.lines, lines)
+		}
+
+		if !reflect.DeepEqual(report, test.report) || report.String() != test.text {
+			t.Errorf("Normalise(%q) report:\nwant %s\ngot  %s\n", test.input, test.text, report)
+		}
+
+		if report.Changed() != (test.text != "no changes") {
+			t.Errorf("Changed(%q):\nwant %t\ngot  %t\n", test.input, !report.Changed(), report.Changed())
+		}
+	}
+}
+
+// TestReadNormalised() reads a file saved with Windows line endings.
+func TestReadNormalised(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "day.input")
+	if err := os.WriteFile(filename, []byte("seeds: 1 2\r\n\r\nx-to-y map:\r\n1 2 3\r\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	lines, report, err := ReadNormalised(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"seeds: 1 2", "", "x-to-y map:", "1 2 3"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("ReadNormalised():\nwant %q\ngot  %q\n", want, lines)
+	}
+
+	if len(report.CarriageReturns) != 4 {
+		t.Errorf("ReadNormalised():\nwant '\\r' removed from 4 lines\ngot  %s\n", report)
+	}
+
+	if _, _, err := ReadNormalised(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("ReadNormalised() of a missing file: expected an error\n")
+	}
+}
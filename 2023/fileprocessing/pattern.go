@@ -0,0 +1,390 @@
+package fileprocessing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Pattern matches a line against a template such as 'Game {id:int}: {rest}'. Text
+// outside the braces must appear in the line as written, except that a run of spaces
+// matches any run of whitespace. Each field in braces captures part of the line:
+//
+//	{name}      text up to the next literal text in the pattern, with surrounding
+//	            whitespace trimmed; a field followed by another field captures a
+//	            single word and a field at the end of the pattern captures the rest of
+//	            the line
+//	{name:int}  a signed integer, which may be preceded by whitespace
+//
+// '{{' and '}}' match a literal brace. Whitespace at the start and end of a line is
+// ignored. Errors give the column (starting from 1) at which the line stopped matching.
+type Pattern struct {
+	text   string
+	tokens []token
+	fields []token
+}
+
+type tokenKind int
+
+const (
+	literalToken tokenKind = iota
+	spaceToken
+	stringField
+	intField
+)
+
+// token is a piece of a Pattern - literal text, a run of spaces or a field.
+type token struct {
+	kind tokenKind
+	text string // the literal text or the name of the field
+}
+
+// NewPattern() compiles a pattern, returning an error if it isn't valid.
+func NewPattern(pattern string) (*Pattern, error) {
+	p := &Pattern{text: pattern}
+	seen := map[string]bool{}
+
+	var literal strings.Builder
+	addLiteral := func() {
+		if literal.Len() > 0 {
+			p.tokens = append(p.tokens, token{kind: literalToken, text: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == ' ':
+			addLiteral()
+			for i+1 < len(pattern) && pattern[i+1] == ' ' {
+				i++
+			}
+
+			p.tokens = append(p.tokens, token{kind: spaceToken})
+
+		case (c == '{' || c == '}') && i+1 < len(pattern) && pattern[i+1] == c:
+			literal.WriteByte(c)
+			i++
+
+		case c == '}':
+			return nil, fmt.Errorf("pattern '%s': column %d: unmatched '}'", pattern, i+1)
+
+		case c == '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("pattern '%s': column %d: unterminated field", pattern, i+1)
+			}
+
+			name, kind, _ := strings.Cut(pattern[i+1:i+end], ":")
+			t := token{kind: stringField, text: name}
+			switch kind {
+			case "", "string":
+			case "int":
+				t.kind = intField
+			default:
+				return nil, fmt.Errorf("pattern '%s': column %d: unknown field type '%s'", pattern, i+1, kind)
+			}
+
+			if name == "" || seen[name] {
+				return nil, fmt.Errorf("pattern '%s': column %d: fields need a unique name", pattern, i+1)
+			}
+
+			addLiteral()
+			if n := len(p.tokens); n > 0 && p.tokens[n-1].kind >= stringField {
+				return nil, fmt.Errorf("pattern '%s': column %d: fields must be separated by text", pattern, i+1)
+			}
+
+			seen[name] = true
+			p.fields = append(p.fields, t)
+			p.tokens = append(p.tokens, t)
+			i += end
+
+		default:
+			literal.WriteByte(c)
+		}
+	}
+
+	addLiteral()
+
+	return p, nil
+}
+
+// MustPattern() compiles a pattern like NewPattern(), but panics if it isn't valid. It's
+// meant for patterns in package variables.
+func MustPattern(pattern string) *Pattern {
+	p, err := NewPattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// String() returns the text of the pattern.
+func (p *Pattern) String() string {
+	return p.text
+}
+
+// Field is the part of a line captured by one of a Pattern's fields.
+type Field struct {
+	Name string
+	Text string
+	// Column is where the field's text starts in the line, starting from 1.
+	Column int
+	// Int is the value of an int field.
+	Int int
+}
+
+// Match is the result of matching a line against a Pattern.
+type Match struct {
+	Fields []Field
+}
+
+// Field() returns the named field. If the pattern has no such field, ok is false.
+func (m *Match) Field(name string) (field Field, ok bool) {
+	for _, f := range m.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+
+	return field, false
+}
+
+// String() returns the text of the named field, or an empty string if there isn't one.
+func (m *Match) String(name string) string {
+	f, _ := m.Field(name)
+	return f.Text
+}
+
+// Int() returns the value of the named int field, or 0 if there isn't one.
+func (m *Match) Int(name string) int {
+	f, _ := m.Field(name)
+	return f.Int
+}
+
+// Match() matches the whole of a line against the pattern.
+func (p *Pattern) Match(line string) (*Match, error) {
+	m := &Match{}
+	pos := skipSpace(line, 0)
+
+	for i, t := range p.tokens {
+		switch t.kind {
+		case literalToken:
+			if !strings.HasPrefix(line[pos:], t.text) {
+				return nil, fmt.Errorf("column %d: expected '%s'", pos+1, t.text)
+			}
+
+			pos += len(t.text)
+
+		case spaceToken:
+			next := skipSpace(line, pos)
+			if next == pos && pos < len(line) {
+				return nil, fmt.Errorf("column %d: expected whitespace", pos+1)
+			}
+
+			pos = next
+
+		case intField:
+			start := skipSpace(line, pos)
+			end := start
+			if end < len(line) && (line[end] == '-' || line[end] == '+') {
+				end++
+			}
+
+			for end < len(line) && isDigit(line[end]) {
+				end++
+			}
+
+			value, err := strconv.Atoi(line[start:end])
+			if err != nil {
+				if end == start || !isDigit(line[end-1]) {
+					return nil, fmt.Errorf("column %d: expected an integer for '%s'", start+1, t.text)
+				}
+
+				return nil, fmt.Errorf("column %d: %w", start+1, err)
+			}
+
+			m.Fields = append(m.Fields, Field{Name: t.text, Text: line[start:end], Column: start + 1, Int: value})
+			pos = end
+
+		case stringField:
+			start := skipSpace(line, pos)
+			end, err := p.fieldEnd(line, start, i)
+			if err != nil {
+				return nil, err
+			}
+
+			var text string
+			if end > start {
+				text = strings.TrimRightFunc(line[start:end], unicode.IsSpace)
+			}
+
+			m.Fields = append(m.Fields, Field{Name: t.text, Text: text, Column: start + 1})
+			pos = end
+		}
+	}
+
+	if rest := skipSpace(line, pos); rest < len(line) {
+		return nil, fmt.Errorf("column %d: unexpected '%s'", rest+1, line[rest:])
+	}
+
+	return m, nil
+}
+
+// fieldEnd() finds the end of the text field at index 'i' of the tokens, which starts at
+// 'start' in the line. The field runs up to the next
+// literal text in the pattern - which must follow whitespace if there's a space before
+// it in the pattern - or is a single word if a field comes next instead.
+func (p *Pattern) fieldEnd(line string, start int, i int) (int, error) {
+	j := i + 1
+	if j == len(p.tokens) {
+		return len(line), nil
+	}
+
+	spaced := p.tokens[j].kind == spaceToken
+	if spaced {
+		j++
+	}
+
+	if j == len(p.tokens) || p.tokens[j].kind != literalToken {
+		if n := strings.IndexFunc(line[start:], unicode.IsSpace); n >= 0 {
+			return start + n, nil
+		}
+
+		return len(line), nil
+	}
+
+	literal := p.tokens[j].text
+	for from := start; ; {
+		n := strings.Index(line[from:], literal)
+		if n < 0 {
+			break
+		}
+
+		at := from + n
+		if !spaced {
+			return at, nil
+		}
+
+		if at == start || unicode.IsSpace(rune(line[at-1])) {
+			return len(strings.TrimRightFunc(line[:at], unicode.IsSpace)), nil
+		}
+
+		from = at + 1
+	}
+
+	return 0, fmt.Errorf("column %d: expected '%s' after '%s'", len(strings.TrimRightFunc(line, unicode.IsSpace))+1, literal, p.tokens[i].text)
+}
+
+// Scan() matches a line against the pattern and stores the fields, in order, in 'dest'.
+// Each destination must be a *string or, for an int field, an *int.
+func (p *Pattern) Scan(line string, dest ...any) error {
+	if len(dest) != len(p.fields) {
+		return fmt.Errorf("pattern '%s' has %d fields, but %d destinations were given", p.text, len(p.fields), len(dest))
+	}
+
+	m, err := p.Match(line)
+	if err != nil {
+		return err
+	}
+
+	for i, f := range m.Fields {
+		switch d := dest[i].(type) {
+		case *string:
+			*d = f.Text
+		case *int:
+			if p.fields[i].kind != intField {
+				return fmt.Errorf("pattern '%s': the field '%s' isn't an int", p.text, f.Name)
+			}
+
+			*d = f.Int
+		default:
+			return fmt.Errorf("pattern '%s': can't store the field '%s' in a %T", p.text, f.Name, dest[i])
+		}
+	}
+
+	return nil
+}
+
+// Ints() returns every integer in a line, ignoring any text around them. A '-' or '+'
+// directly before a number is its sign unless it follows a digit, so '3-5' is 3 and 5
+// but 'x=-3' is -3.
+func Ints(line string) ([]int, error) {
+	var values []int
+
+	for i := 0; i < len(line); {
+		start := i
+		if (line[i] == '-' || line[i] == '+') && i+1 < len(line) && isDigit(line[i+1]) && (i == 0 || !isDigit(line[i-1])) {
+			i++
+		}
+
+		if !isDigit(line[i]) {
+			i++
+			continue
+		}
+
+		for i < len(line) && isDigit(line[i]) {
+			i++
+		}
+
+		value, err := strconv.Atoi(line[start:i])
+		if err != nil {
+			return nil, fmt.Errorf("column %d: %w", start+1, err)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// IntError is the error ParseInts() returns for something that isn't an integer.
+type IntError struct {
+	// Column is where the text starts in the line, starting from 1.
+	Column int
+	Text   string
+}
+
+func (e *IntError) Error() string {
+	return fmt.Sprintf("column %d: '%s' isn't an integer", e.Column, e.Text)
+}
+
+// ParseInts() parses a line of whitespace-separated integers, however they are spaced.
+// Unlike Ints(), anything that isn't an integer is an error, which is an *IntError.
+func ParseInts(line string) ([]int, error) {
+	var values []int
+
+	for pos := skipSpace(line, 0); pos < len(line); pos = skipSpace(line, pos) {
+		start := pos
+		for pos < len(line) && !unicode.IsSpace(rune(line[pos])) {
+			pos++
+		}
+
+		value, err := strconv.Atoi(line[start:pos])
+		if err != nil {
+			return nil, &IntError{Column: start + 1, Text: line[start:pos]}
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// skipSpace() returns the index of the first character at or after 'pos' that isn't
+// whitespace.
+func skipSpace(line string, pos int) int {
+	for pos < len(line) && unicode.IsSpace(rune(line[pos])) {
+		pos++
+	}
+
+	return pos
+}
+
+// isDigit() reports whether a byte is a decimal digit.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,158 @@
+package fileprocessing
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var patternTests = []struct {
+	pattern string
+	line    string
+	fields  []Field
+	err     string
+}{
+	{
+		"Game {id:int}: {rest}", "Game 12: 3 blue, 4 red; 1 red",
+		[]Field{{Name: "id", Text: "12", Column: 6, Int: 12}, {Name: "rest", Text: "3 blue, 4 red; 1 red", Column: 10}}, "",
+	},
+	{
+		"fold along {axis}={line:int}", "  fold along   Y = 7 ",
+		[]Field{{Name: "axis", Text: "Y", Column: 16}, {Name: "line", Text: "7", Column: 20, Int: 7}}, "",
+	},
+	{
+		"--- scanner {id:int} ---", "--- scanner 10 ---",
+		[]Field{{Name: "id", Text: "10", Column: 13, Int: 10}}, "",
+	},
+	{
+		"{x:int},{y:int},{z:int}", "-618,-824,+621",
+		[]Field{{Name: "x", Text: "-618", Column: 1, Int: -618}, {Name: "y", Text: "-824", Column: 6, Int: -824}, {Name: "z", Text: "+621", Column: 11, Int: 621}}, "",
+	},
+	{
+		"{name} is {age:int}", "Bob is 42",
+		[]Field{{Name: "name", Text: "Bob", Column: 1}, {Name: "age", Text: "42", Column: 8, Int: 42}}, "",
+	},
+	{
+		"{name} is {age:int}", "Isis is 7",
+		[]Field{{Name: "name", Text: "Isis", Column: 1}, {Name: "age", Text: "7", Column: 9, Int: 7}}, "",
+	},
+	{
+		"{first} {second}: {rest}", "Ada Lovelace: maths",
+		[]Field{{Name: "first", Text: "Ada", Column: 1}, {Name: "second", Text: "Lovelace", Column: 5}, {Name: "rest", Text: "maths", Column: 15}}, "",
+	},
+	{
+		"{{{name}}}", "{brace}",
+		[]Field{{Name: "name", Text: "brace", Column: 2}}, "",
+	},
+	{"Game {id:int}: {rest}", "Game x: 3 blue", nil, "column 6: expected an integer for 'id'"},
+	{"Game {id:int}: {rest}", "Game 1 3 blue", nil, "column 7: expected ':'"},
+	{"Game {id:int}: {rest}", "Gam 1: 3 blue", nil, "column 1: expected 'Game'"},
+	{"fold along {axis}={line:int}", "fold along y7", nil, "column 14: expected '=' after 'axis'"},
+	{"fold along {axis}={line:int}", "fold x=3", nil, "column 6: expected 'along'"},
+	{"fold along {axis}={line:int}", "fold along x=3 please", nil, "column 16: unexpected 'please'"},
+	{"{a:int}", "99999999999999999999", nil, "column 1: strconv.Atoi: parsing \"99999999999999999999\": value out of range"},
+}
+
+// TestPattern() matches lines against patterns and checks the columns in the errors.
+func TestPattern(t *testing.T) {
+	for _, test := range patternTests {
+		m, err := MustPattern(test.pattern).Match(test.line)
+
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("Match(%q, %q):\nwant %s\ngot  %v\n", test.pattern, test.line, test.err, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Match(%q, %q):\nwant %v\ngot  %v\n", test.pattern, test.line, test.fields, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(m.Fields, test.fields) {
+			t.Errorf("Match(%q, %q):\nwant %+v\ngot  %+v\n", test.pattern, test.line, test.fields, m.Fields)
+		}
+	}
+}
+
+// TestNewPattern() checks that invalid patterns are rejected.
+func TestNewPattern(t *testing.T) {
+	for _, pattern := range []string{"{x", "x}", "{}", "{x:float}", "{x}{y}", "{x} {x}"} {
+		if _, err := NewPattern(pattern); err == nil {
+			t.Errorf("NewPattern(%q): expected an error\n", pattern)
+		}
+	}
+}
+
+// TestScan() stores the fields of a match in variables.
+func TestScan(t *testing.T) {
+	p := MustPattern("Card {id:int}: {winning} | {numbers}")
+
+	var id int
+	var winning, numbers string
+	if err := p.Scan("Card  3:  1 21 | 83 86", &id, &winning, &numbers); err != nil {
+		t.Fatal(err)
+	}
+
+	if id != 3 || winning != "1 21" || numbers != "83 86" {
+		t.Errorf("Scan():\nwant 3, '1 21', '83 86'\ngot  %d, '%s', '%s'\n", id, winning, numbers)
+	}
+
+	if err := p.Scan("Card 4:  | 2", &id, &winning, &numbers); err != nil || winning != "" || numbers != "2" {
+		t.Errorf("Scan() with no winning numbers:\nwant '', '2'\ngot  '%s', '%s' (%v)\n", winning, numbers, err)
+	}
+
+	if err := p.Scan("Card 3: 1 | 2", &id, &winning); err == nil {
+		t.Errorf("Scan() with too few destinations: expected an error\n")
+	}
+
+	if err := p.Scan("Card 3: 1 | 2", &id, &id, &numbers); err == nil || !strings.Contains(err.Error(), "isn't an int") {
+		t.Errorf("Scan() with an *int for a text field:\nwant an error\ngot  %v\n", err)
+	}
+}
+
+var intsTests = []struct {
+	line string
+	ints []int
+}{
+	{"", nil},
+	{"no numbers", nil},
+	{"target area: x=20..30, y=-10..-5", []int{20, 30, -10, -5}},
+	{"3-5 +7 - 8 -", []int{3, 5, 7, 8}},
+	{"on x=-54112..-39298,y=+12", []int{-54112, -39298, 12}},
+}
+
+// TestInts() pulls the signed integers out of lines.
+func TestInts(t *testing.T) {
+	for _, test := range intsTests {
+		ints, err := Ints(test.line)
+		if err != nil || !reflect.DeepEqual(ints, test.ints) {
+			t.Errorf("Ints(%q):\nwant %v\ngot  %v (%v)\n", test.line, test.ints, ints, err)
+		}
+	}
+
+	if _, err := Ints("x=1 y=-99999999999999999999"); err == nil || !strings.HasPrefix(err.Error(), "column 7:") {
+		t.Errorf("Ints():\nwant an error at column 7\ngot  %v\n", err)
+	}
+}
+
+// TestParseInts() parses numbers regardless of their spacing and rejects anything else.
+func TestParseInts(t *testing.T) {
+	ints, err := ParseInts("  1 21\t-53   59 ")
+	if want := []int{1, 21, -53, 59}; err != nil || !reflect.DeepEqual(ints, want) {
+		t.Errorf("ParseInts():\nwant %v\ngot  %v (%v)\n", want, ints, err)
+	}
+
+	_, err = ParseInts("1 2x 3")
+	if err == nil || err.Error() != "column 3: '2x' isn't an integer" {
+		t.Errorf("ParseInts():\nwant column 3: '2x' isn't an integer\ngot  %v\n", err)
+	}
+
+	var intErr *IntError
+	if !errors.As(err, &intErr) || intErr.Column != 3 || intErr.Text != "2x" {
+		t.Errorf("ParseInts():\nwant an *IntError at column 3\ngot  %#v\n", err)
+	}
+}
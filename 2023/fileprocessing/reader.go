@@ -0,0 +1,189 @@
+package fileprocessing
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrLineTooLong is returned when a line is longer than a LineReader's MaxLineSize.
+var ErrLineTooLong = errors.New("line too long")
+
+// LineReader reads an io.Reader one line at a time, so a file never has to be held in
+// memory. Unlike a default bufio.Scanner there's no limit on the length of a line
+// unless MaxLineSize is set. Line endings ('\n' or '\r\n') are removed.
+//
+// Like a bufio.Scanner, Scan() is called until it returns false and then Err()
+// reports the error that stopped it, if any:
+//
+//	lr := fileprocessing.NewLineReader(r)
+//	for lr.Scan() {
+//		line := lr.Text()
+//	}
+//
+//	if err := lr.Err(); err != nil {
+//		...
+//	}
+type LineReader struct {
+	// MaxLineSize is the length in bytes of the longest line allowed, or 0 for no limit.
+	MaxLineSize int
+
+	r          *bufio.Reader
+	line       string
+	ending     string
+	lineNumber int
+	err        error
+}
+
+// NewLineReader() creates a LineReader for 'r' with no limit on the length of a line.
+func NewLineReader(r io.Reader) *LineReader {
+	return &LineReader{r: bufio.NewReader(r)}
+}
+
+// Scan() reads the next line, returning false at the end of the input or on an error.
+func (lr *LineReader) Scan() bool {
+	if lr.err != nil {
+		return false
+	}
+
+	var line []byte
+	for {
+		chunk, err := lr.r.ReadSlice('\n')
+		line = append(line, chunk...)
+
+		if lr.MaxLineSize > 0 && contentLength(line) > lr.MaxLineSize {
+			lr.err = fmt.Errorf("line %d: %w (the maximum is %d bytes)", lr.lineNumber+1, ErrLineTooLong, lr.MaxLineSize)
+			return false
+		}
+
+		if err == bufio.ErrBufferFull {
+			// the line is longer than the buffer - keep reading
+			continue
+		}
+
+		if err == io.EOF && len(line) == 0 {
+			lr.err = io.EOF
+			return false
+		}
+
+		if err != nil && err != io.EOF {
+			lr.err = fmt.Errorf("line %d: %w", lr.lineNumber+1, err)
+			return false
+		}
+
+		break
+	}
+
+	lr.lineNumber++
+	lr.line = strings.TrimSuffix(strings.TrimSuffix(string(line), "\n"), "\r")
+	lr.ending = string(line[len(lr.line):])
+
+	return true
+}
+
+// contentLength() returns the length of a line without its line ending.
+func contentLength(line []byte) int {
+	n := len(line)
+	if n > 0 && line[n-1] == '\n' {
+		n--
+		if n > 0 && line[n-1] == '\r' {
+			n--
+		}
+	}
+
+	return n
+}
+
+// Text() returns the line read by the latest call to Scan().
+func (lr *LineReader) Text() string {
+	return lr.line
+}
+
+// Ending() returns the line ending removed from the line read by the latest call to
+// Scan() - "\n", "\r\n", or "" for a last line that doesn't end with a newline.
+func (lr *LineReader) Ending() string {
+	return lr.ending
+}
+
+// LineNumber() returns the number of the line read by the latest call to Scan(),
+// starting from 1.
+func (lr *LineReader) LineNumber() int {
+	return lr.lineNumber
+}
+
+// Err() returns the error that stopped the LineReader, or nil if it reached the end of
+// the input.
+func (lr *LineReader) Err() error {
+	if lr.err == io.EOF {
+		return nil
+	}
+
+	return lr.err
+}
+
+// EachLine() calls 'f' with each line of 'r' and its line number, stopping at the first
+// error from reading or from 'f'.
+func EachLine(r io.Reader, f func(lineNumber int, line string) error) error {
+	lr := NewLineReader(r)
+	for lr.Scan() {
+		if err := f(lr.LineNumber(), lr.Text()); err != nil {
+			return err
+		}
+	}
+
+	return lr.Err()
+}
+
+// ReadLines() reads every line of 'r'.
+func ReadLines(r io.Reader) ([]string, error) {
+	var lines []string
+
+	err := EachLine(r, func(_ int, line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+
+	return lines, err
+}
+
+// ReadBytes() reads the whole of the named file.
+func ReadBytes(filename string) ([]byte, error) {
+	return os.ReadFile(filename)
+}
+
+// ReadString() reads the whole of the named file as a single string.
+func ReadString(filename string) (string, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+
+	return string(contents), nil
+}
+
+// ReadBlocks() reads the named file as blocks of lines separated by one or more blank
+// lines. The blank lines aren't included in the blocks. Use Sections() to keep the line
+// numbers of the blocks.
+func ReadBlocks(filename string) ([][]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	lines, err := ReadLines(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	var blocks [][]string
+	for _, s := range Sections(lines) {
+		blocks = append(blocks, s.Lines)
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,86 @@
+package fileprocessing
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Section is a block of lines in an input that is separated from the other blocks by
+// one or more blank lines. A headed section keeps its first line (e.g. 'seed-to-soil
+// map:') apart from the lines that follow it.
+type Section struct {
+	// Header is the first line of a headed section, or empty if the section isn't headed.
+	Header string
+	// HeaderLine is the line number of the Header, or 0 if the section isn't headed.
+	HeaderLine int
+
+	// Lines are the lines of the section after the header, if there is one.
+	Lines []string
+	// LineNumbers holds the line number of each of the Lines, starting from 1.
+	LineNumbers []int
+}
+
+// Sections() splits lines into sections separated by one or more blank lines. Blank
+// lines (including whitespace-only ones) at the start or end of the input are ignored
+// and a '\r' left at the end of a line is removed.
+func Sections(lines []string) []Section {
+	var sections []Section
+	var current *Section
+
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if len(strings.TrimSpace(line)) == 0 {
+			current = nil
+			continue
+		}
+
+		if current == nil {
+			sections = append(sections, Section{})
+			current = &sections[len(sections)-1]
+		}
+
+		current.Lines = append(current.Lines, line)
+		current.LineNumbers = append(current.LineNumbers, i+1)
+	}
+
+	return sections
+}
+
+// HeadedSections() splits lines into sections like Sections(), with the first line of
+// each section as its header.
+func HeadedSections(lines []string) []Section {
+	sections := Sections(lines)
+	for i := range sections {
+		s := &sections[i]
+
+		s.Header, s.HeaderLine = s.Lines[0], s.LineNumbers[0]
+		s.Lines, s.LineNumbers = s.Lines[1:], s.LineNumbers[1:]
+	}
+
+	return sections
+}
+
+// Start() returns the line number of the first line of the section, including its header.
+func (s Section) Start() int {
+	if s.HeaderLine > 0 {
+		return s.HeaderLine
+	}
+
+	if len(s.LineNumbers) > 0 {
+		return s.LineNumbers[0]
+	}
+
+	return 0
+}
+
+// Errorf() returns an error for the line at index 'i' of the section's Lines, prefixed
+// with its line number in the input like the other errors in this package. An index of
+// -1 refers to the header.
+func (s Section) Errorf(i int, format string, a ...any) error {
+	lineNumber := s.HeaderLine
+	if i >= 0 && i < len(s.LineNumbers) {
+		lineNumber = s.LineNumbers[i]
+	}
+
+	return fmt.Errorf("line %d: %w", lineNumber, fmt.Errorf(format, a...))
+}
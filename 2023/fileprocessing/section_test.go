@@ -0,0 +1,79 @@
+package fileprocessing
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var sectionsTests = []struct {
+	input       string
+	lines       [][]string
+	lineNumbers [][]int
+}{
+	{"", nil, nil},
+	{"\n\n", nil, nil},
+	{"a\nb", [][]string{{"a", "b"}}, [][]int{{1, 2}}},
+	{"a\n\nb\nc\n", [][]string{{"a"}, {"b", "c"}}, [][]int{{1}, {3, 4}}},
+	{"\n\na\n \n\t\nb\n\n\n", [][]string{{"a"}, {"b"}}, [][]int{{3}, {6}}},
+	{"a\r\nb\r\n\r\nc\r\n\r\n", [][]string{{"a", "b"}, {"c"}}, [][]int{{1, 2}, {4}}},
+}
+
+// TestSections() checks the splitting on blank lines, including blank lines at the start
+// and end of the input and CRLF line endings.
+func TestSections(t *testing.T) {
+	for _, test := range sectionsTests {
+		sections := Sections(strings.Split(test.input, "\n"))
+
+		var lines [][]string
+		var lineNumbers [][]int
+		for _, s := range sections {
+			if s.Header != "" || s.HeaderLine != 0 {
+				t.Errorf("Sections(%q):\nwant no header\ngot  %q on line %d\n", test.input, s.Header, s.HeaderLine)
+			}
+
+			lines = append(lines, s.Lines)
+			lineNumbers = append(lineNumbers, s.LineNumbers)
+		}
+
+		if !reflect.DeepEqual(lines, test.lines) {
+			t.Errorf("Sections(%q):\nwant %q\ngot  %q\n", test.input, test.lines, lines)
+		}
+
+		if !reflect.DeepEqual(lineNumbers, test.lineNumbers) {
+			t.Errorf("Sections(%q) line numbers:\nwant %v\ngot  %v\n", test.input, test.lineNumbers, lineNumbers)
+		}
+	}
+}
+
+// TestHeadedSections() checks that the header is split from the lines, including for a
+// section that is only a header.
+func TestHeadedSections(t *testing.T) {
+	input := "seeds: 79 14\r\n\r\nseed-to-soil map:\r\n50 98 2\r\n52 50 48\r\n\r\n"
+
+	sections := HeadedSections(strings.Split(input, "\n"))
+	if len(sections) != 2 {
+		t.Fatalf("HeadedSections():\nwant 2 sections\ngot  %d\n", len(sections))
+	}
+
+	if s := sections[0]; s.Header != "seeds: 79 14" || s.HeaderLine != 1 || len(s.Lines) != 0 || s.Start() != 1 {
+		t.Errorf("HeadedSections() first section:\nwant the header 'seeds: 79 14' on line 1 and no lines\ngot  %+v\n", s)
+	}
+
+	s := sections[1]
+	if s.Header != "seed-to-soil map:" || s.HeaderLine != 3 || s.Start() != 3 {
+		t.Errorf("HeadedSections() second section:\nwant the header 'seed-to-soil map:' on line 3\ngot  %q on line %d\n", s.Header, s.HeaderLine)
+	}
+
+	if !reflect.DeepEqual(s.Lines, []string{"50 98 2", "52 50 48"}) || !reflect.DeepEqual(s.LineNumbers, []int{4, 5}) {
+		t.Errorf("HeadedSections() second section:\nwant lines 4 and 5\ngot  %q %v\n", s.Lines, s.LineNumbers)
+	}
+
+	if err := s.Errorf(1, "bad mapping '%s'", s.Lines[1]); err.Error() != "line 5: bad mapping '52 50 48'" {
+		t.Errorf("Errorf():\nwant line 5: bad mapping '52 50 48'\ngot  %v\n", err)
+	}
+
+	if err := s.Errorf(-1, "bad header"); err.Error() != "line 3: bad header" {
+		t.Errorf("Errorf():\nwant line 3: bad header\ngot  %v\n", err)
+	}
+}
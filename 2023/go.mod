@@ -4,8 +4,10 @@ go 1.21
 
 require (
     fileprocessing v0.0.0
+    sciencerocketry.com/explain v0.0.0
 )
 
 replace (
     fileprocessing => ./fileprocessing
+    sciencerocketry.com/explain => ../2021/explain
 )
\ No newline at end of file
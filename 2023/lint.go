@@ -0,0 +1,204 @@
+// this file implements the 'lint' command, which checks each day's input against the
+// format the day declares before anything tries to solve it.
+
+package main
+
+import (
+	"errors"
+	"fileprocessing"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// maxProblems is the most problems reported for a single input
+const maxProblems = 10
+
+// lineFormat declares what a line of an input looks like
+type lineFormat struct {
+	pattern *fileprocessing.Pattern
+	// check makes any checks the pattern can't, such as a field being a list of numbers
+	check func(m *fileprocessing.Match) error
+}
+
+// sectionFormat declares a block of lines in an input. If header is set, the first line
+// of the block is checked against it and the rest against lines. A nil lines means the
+// section is only a header.
+type sectionFormat struct {
+	header *lineFormat
+	lines  *lineFormat
+	// sameLength requires every line to be the same length, as in a grid
+	sameLength bool
+}
+
+// inputFormat declares the format of a day's input as the sections it's made of,
+// separated by blank lines. An input without blank lines is a single section. If
+// repeat is set, the last section can appear any number of times.
+type inputFormat struct {
+	sections []sectionFormat
+	repeat   bool
+}
+
+// checkInts() returns a check that each of the named fields is a list of integers
+// separated by any amount of whitespace
+func checkInts(names ...string) func(m *fileprocessing.Match) error {
+	return func(m *fileprocessing.Match) error {
+		for _, name := range names {
+			field, _ := m.Field(name)
+
+			if _, err := fileprocessing.ParseInts(field.Text); err != nil {
+				var intErr *fileprocessing.IntError
+				if errors.As(err, &intErr) {
+					// the column is within the field, rather than the line
+					return &fileprocessing.IntError{Column: intErr.Column + field.Column - 1, Text: intErr.Text}
+				}
+
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// checkItems() returns a check that the named field is a list of items separated by any
+// of the characters in 'separators', each of which matches 'item'
+func checkItems(name string, separators string, item *fileprocessing.Pattern) func(m *fileprocessing.Match) error {
+	return func(m *fileprocessing.Match) error {
+		field, _ := m.Field(name)
+
+		for start := 0; start <= len(field.Text); {
+			end := strings.IndexAny(field.Text[start:], separators)
+			if end < 0 {
+				end = len(field.Text)
+			} else {
+				end += start
+			}
+
+			if _, err := item.Match(field.Text[start:end]); err != nil {
+				column := field.Column + start + len(field.Text[start:end]) - len(strings.TrimLeftFunc(field.Text[start:end], unicode.IsSpace))
+				return fmt.Errorf("column %d: '%s' doesn't match '%s'", column, strings.TrimSpace(field.Text[start:end]), item)
+			}
+
+			start = end + 1
+		}
+
+		return nil
+	}
+}
+
+// lineFormat.lint() checks a single line
+func (f *lineFormat) lint(line string) error {
+	m, err := f.pattern.Match(line)
+	if err != nil {
+		return err
+	}
+
+	if f.check != nil {
+		return f.check(m)
+	}
+
+	return nil
+}
+
+// sectionFormat.lint() checks each of the lines of a section
+func (f *sectionFormat) lint(s fileprocessing.Section) []error {
+	var problems []error
+
+	for i, line := range s.Lines {
+		format := f.lines
+		if f.header != nil && i == 0 {
+			format = f.header
+		}
+
+		if format == nil {
+			problems = append(problems, s.Errorf(i, "expected a blank line"))
+			break
+		}
+
+		if err := format.lint(line); err != nil {
+			problems = append(problems, s.Errorf(i, "%w", err))
+		}
+
+		if f.sameLength && len(line) != len(s.Lines[0]) {
+			problems = append(problems, s.Errorf(i, "the line is %d characters long, but the first is %d", len(line), len(s.Lines[0])))
+		}
+	}
+
+	return problems
+}
+
+// inputFormat.lint() checks the lines of an input against the format, returning the
+// problems found
+func (f *inputFormat) lint(lines []string) []error {
+	sections := fileprocessing.Sections(lines)
+	if len(sections) == 0 {
+		return []error{fmt.Errorf("the input is empty")}
+	}
+
+	var problems []error
+	for i, s := range sections {
+		if i >= len(f.sections) && !f.repeat {
+			problems = append(problems, s.Errorf(0, "expected the end of the input"))
+			break
+		}
+
+		format := f.sections[min(i, len(f.sections)-1)]
+		problems = append(problems, format.lint(s)...)
+	}
+
+	if len(sections) < len(f.sections) {
+		problems = append(problems, fmt.Errorf("expected %d sections separated by blank lines, but there are %d", len(f.sections), len(sections)))
+	}
+
+	return problems
+}
+
+// exercise.lint() writes what normalising the exercise's input changed and checks the
+// lines that were read against the exercise's format. It returns false if there are
+// problems.
+func (e *exercise) lint(w io.Writer, lines []string, report fileprocessing.Report) bool {
+	fmt.Fprintf(w, "%s (%s): %s\n", e.name, e.input, report)
+
+	if e.format == nil {
+		fmt.Fprintf(w, "  no format is declared\n")
+		return true
+	}
+
+	problems := e.format.lint(lines)
+	for i, problem := range problems {
+		if i == maxProblems {
+			fmt.Fprintf(w, "  ... and %d more\n", len(problems)-maxProblems)
+			break
+		}
+
+		fmt.Fprintf(w, "  %v\n", problem)
+	}
+
+	if len(problems) == 0 {
+		fmt.Fprintf(w, "  the format is ok\n")
+	}
+
+	return len(problems) == 0
+}
+
+// lintExercises() lints the input of every exercise, returning false if any of them
+// have problems
+func lintExercises(exercises []exercise, w io.Writer) bool {
+	ok := true
+	for i := range exercises {
+		lines, report, err := fileprocessing.ReadNormalised(exercises[i].input)
+		if err != nil {
+			fmt.Fprintf(w, "%s: %v\n", exercises[i].name, err)
+			ok = false
+			continue
+		}
+
+		if !exercises[i].lint(w, lines, report) {
+			ok = false
+		}
+	}
+
+	return ok
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+var lintTests = []struct {
+	name     string
+	format   *inputFormat
+	input    string
+	problems []string
+}{
+	{"day 1", day1Format, "1abc2\npqr3stu8vwx\na1b2c3d4e5f\ntreb7uchet", nil},
+	{"day 1 with other letters", day1Format, "1abc2\nfünf3-Stu8vwx", nil},
+	{"day 1 with a space", day1Format, "1abc2\npqr3 stu8vwx", []string{"line 2: column 5: unexpected ' '"}},
+	{"day 1 with a control character", day1Format, "1abc2\npqr3\astu8vwx", []string{"line 2: column 5: unexpected '\\a'"}},
+	{"day 1 with a blank line", day1Format, "1abc2\n\npqr3stu8vwx", []string{"line 3: expected the end of the input"}},
+	{"day 2", day2Format, "Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green\nGame 2: 1 blue, 2 green", nil},
+	{"day 2 with a bad count", day2Format, "Game 1: 3 blue, 4 red\nGame 2: 1 blue; green", []string{"line 2: column 17: 'green' doesn't match '{count:int} {colour}'"}},
+	{"day 2 with no id", day2Format, "Game: 3 blue", []string{"line 1: column 5: expected whitespace"}},
+	{"day 3", day3Format, "467..114..\n...*......\n..35..633.", nil},
+	{"day 3 with a short row", day3Format, "467..114..\n...*.....\n..35..633.", []string{"line 2: the line is 9 characters long, but the first is 10"}},
+	{"day 3 with a space", day3Format, "467..114..\n...* .....", []string{"line 2: column 6: unexpected '.....'"}},
+	{"day 4", day4Format, "Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53\nCard   2:  3 32 | 61  1", nil},
+	{"day 4 with a letter", day4Format, "Card 1: 41 48 | 83 8x", []string{"line 1: column 20: '8x' isn't an integer"}},
+	{"day 4 with no bar", day4Format, "Card 1: 41 48 83 86", []string{"line 1: column 20: expected '|' after 'winning'"}},
+	{"day 5", day5Format, "seeds: 79 14\n\nseed-to-soil map:\n50 98 2\n52 50 48\n\nsoil-to-fertilizer map:\n0 15 37\n", nil},
+	{"day 5 with no maps", day5Format, "seeds: 79 14", []string{"expected 2 sections separated by blank lines, but there are 1"}},
+	{"day 5 with a bad map", day5Format, "seeds: 79 14\n\nseed-to-soil map:\n50 98\n\nsoil to fertilizer map:\n0 15 37", []string{
+		"line 4: column 6: expected an integer for 'length'",
+		"line 6: column 24: expected '-to-' after 'source'",
+	}},
+	{"day 5 with seeds on two lines", day5Format, "seeds: 79 14\n55 13\n\nseed-to-soil map:\n50 98 2", []string{"line 2: expected a blank line"}},
+	{"empty", day1Format, "\n\n", []string{"the input is empty"}},
+}
+
+// TestLint() checks the declared formats accept the examples from the puzzles and
+// that the problems with broken inputs point at the right line and column.
+func TestLint(t *testing.T) {
+	for _, test := range lintTests {
+		var problems []string
+		for _, err := range test.format.lint(strings.Split(test.input, "\n")) {
+			problems = append(problems, err.Error())
+		}
+
+		if strings.Join(problems, "\n") != strings.Join(test.problems, "\n") {
+			t.Errorf("lint() %s:\nwant %q\ngot  %q\n", test.name, test.problems, problems)
+		}
+	}
+}
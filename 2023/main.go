@@ -12,30 +12,79 @@
 //
 // Usage:
 //
-//	2023 [input]
+//	2023 [-bag red=12,green=13,blue=14] [-cards cards.json] [-card-tree tree.json [-card-tree-depth 3]]
+//	     [-explain text|json [-explain-to file]] [input | lint]
 //
 // 'input' represents a selection you'd like to run and can be omitted. If it
 // is omitted, a menu is displayed and user input is requested to choose an
 // exercise to run.
+//
+// 'lint' checks every exercise's input against the format the exercise declares
+// and reports anything that had to be cleaned up (such as '\r' line endings)
+// without solving anything.
+//
+// '-bag' sets the cubes in the bag for Day 2's questions. '-cards' and '-card-tree'
+// write the breakdown of each of Day 4's scratchcards and the tree of the cards each
+// one wins as JSON.
+//
+// '-explain' has each part report how every item of the input contributed to its
+// answer, such as each line's calibration value or why a game was impossible. The
+// explanations are written to stderr, or to the file given by '-explain-to', so
+// they're kept apart from the answers.
 package main
 
 import (
 	"bufio"
+	"fileprocessing"
+	"flag"
 	"fmt"
+	"log"
 	"os"
 	"strconv"
 	"strings"
+
+	"sciencerocketry.com/explain"
 )
 
 // main() is where the action starts (and, unless something goes badly, ends).
 func main() {
+	flag.Var(day2Bag, "bag", "the cubes of each colour in the bag for Day 2")
+	flag.StringVar(&day4BreakdownFile, "cards", "", "write the breakdown of each Day 4 card to this `file` as JSON")
+	flag.StringVar(&day4TreeFile, "card-tree", "", "write the tree of the Day 4 cards won to this `file` as JSON")
+	flag.Func("card-tree-depth", fmt.Sprintf("the number of `levels` of wins in the Day 4 tree (default %d)", day4TreeDepth), func(value string) error {
+		depth, err := strconv.Atoi(value)
+		if err != nil || depth < 0 {
+			return fmt.Errorf("'%s' isn't a number of levels - use 0 or more", value)
+		}
+
+		day4TreeDepth = depth
+
+		return nil
+	})
+	explainFormat := flag.String("explain", "", "explain how each item contributed to the answers, as 'text' or 'json'")
+	explainTo := flag.String("explain-to", "", "write the explanations to this `file` instead of stderr")
+	flag.Parse()
+
+	var err error
+	if explanations, err = explain.Open(*explainFormat, *explainTo); err != nil {
+		log.Fatal(err)
+	}
+
+	defer explanations.Close()
+
 	choice := -1
 
 	// check for a command-line argument with a preselection
 	// - this will speed up testing and debugging a new day's solution
-	argCount := len(os.Args)
-	if argCount > 1 {
-		selectionNum, err := strconv.Atoi(os.Args[1])
+	argCount := flag.NArg()
+	if argCount > 0 && flag.Arg(0) == "lint" {
+		if !lintExercises(initializeExercises(), os.Stdout) {
+			os.Exit(1)
+		}
+
+		return
+	} else if argCount > 0 {
+		selectionNum, err := strconv.Atoi(flag.Arg(0))
 		if err != nil {
 			fmt.Println("Invalid choice. Please try again.")
 		} else {
@@ -85,19 +134,37 @@ func main() {
 
 }
 
+// explanations is the Explainer set up by the '-explain' and '-explain-to' flags, or
+// nil if they weren't given. Each run of an exercise gets its own Explainer from it
+// with ForExercise().
+var explanations *explain.Explainer
+
 // exercise is a structure for storing a given day's name, input data, and processing
 // function. An array of these will be used to provide the user a menu as well as handle
 // the processing required to solve the exercise.
 type exercise struct {
 	name   string
 	input  string
-	myFunc func(string, string) string
+	format *inputFormat
+	myFunc func(string, []string, *explain.Explainer) string
 }
 
-// run() executes the solve for a given exercise, passing both the name and input
-// that was associated with the exercise
+// run() executes the solve for a given exercise, passing the name and the lines of
+// the input that was associated with the exercise, along with an explainer for the
+// exercise if explanations were asked for. The lines are checked against the
+// exercise's format first, and if they don't match, the problems are returned instead.
 func (e *exercise) run() string {
-	return e.myFunc(e.name, e.input)
+	var problems strings.Builder
+
+	lines, report, err := fileprocessing.ReadNormalised(e.input)
+	if err != nil {
+		problems.WriteString(fmt.Sprintf("%s: %v\n", e.name, err))
+	} else if e.lint(&problems, lines, report) {
+		return e.myFunc(e.name, lines, explanations.ForExercise(e.name))
+	}
+
+	problems.WriteString(e.name + " wasn't solved because of the problems with its input\n")
+	return problems.String()
 }
 
 // initializeExercises() builds the exercises array
@@ -106,26 +173,31 @@ func initializeExercises() []exercise {
 		{
 			name:   "Day 1",
 			input:  "Day 1/day.input",
+			format: day1Format,
 			myFunc: day1,
 		},
 		{
 			name:   "Day 2",
 			input:  "Day 2/day.input",
+			format: day2Format,
 			myFunc: day2,
 		},
 		{
 			name:   "Day 3",
 			input:  "Day 3/day.input",
+			format: day3Format,
 			myFunc: day3,
 		},
 		{
 			name:   "Day 4",
 			input:  "Day 4/day.input",
+			format: day4Format,
 			myFunc: day4,
 		},
 		{
 			name:   "Day 5",
 			input:  "Day 5/day.input",
+			format: day5Format,
 			myFunc: day5,
 		},
 	}